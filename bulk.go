@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/checkly-go/checkly/pkg/checker"
+	"github.com/checkly-go/checkly/pkg/report"
+)
+
+// runBulk audits every URL in config.URLsFile with the selected checkers and streams each report to the
+// CSV or NDJSON output as soon as it finishes. Progress goes to stderr so stdout stays machine-readable.
+// It reports whether any site has findings at the -fail-on level.
+func runBulk(config Config) bool {
+	urls, err := loadURLs(config.URLsFile)
+	if err != nil {
+		log.Fatalf("Error loading URL list: %v", err)
+	}
+	if len(urls) == 0 {
+		log.Fatalf("No URLs found in %s", config.URLsFile)
+	}
+
+	var writer io.Writer = os.Stdout
+	if config.OutputFile != "" {
+		file, err := os.Create(config.OutputFile)
+		if err != nil {
+			log.Fatalf("Error creating output file: %v", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	var reporter report.Reporter
+	switch config.Output {
+	case "csv":
+		reporter = report.NewCSVReporter(writer)
+	case "ndjson":
+		reporter = report.NewNDJSONReporter(writer)
+	default:
+		log.Fatalf("Bulk runs (-urls) support csv and ndjson output, not %q", config.Output)
+	}
+
+	chk := newBulkChecker(config)
+
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	var done int
+	var failed bool
+	var mu sync.Mutex
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				websiteReport, err := chk.CheckWebsite(url)
+
+				mu.Lock()
+				done++
+				progress := fmt.Sprintf("[%d/%d] %s", done, len(urls), url)
+				if err == nil && failsOn(config.FailOn, *websiteReport) {
+					failed = true
+				}
+				mu.Unlock()
+
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", progress, err)
+					continue
+				}
+				if err := reporter.WriteReport(*websiteReport); err != nil {
+					log.Fatalf("Error writing report for %s: %v", url, err)
+				}
+				fmt.Fprintf(os.Stderr, "%s: score %d (%s)\n", progress, websiteReport.OverallScore, websiteReport.Grade)
+				for _, warning := range websiteReport.SuppressionWarnings {
+					fmt.Fprintf(os.Stderr, "%s: warning: %s\n", progress, warning)
+				}
+			}
+		}()
+	}
+
+	for _, url := range urls {
+		jobs <- url
+	}
+	close(jobs)
+	wg.Wait()
+
+	return failed
+}
+
+// newBulkChecker configures the checker from the command-line options
+func newBulkChecker(config Config) *checker.Checker {
+	chk := checker.NewChecker()
+	chk.Config.APIPaths = config.APIPaths
+	chk.Config.Checkers = config.Checkers
+	chk.Config.ProbeDelay = config.ProbeDelay
+
+	if config.DNSServer != "" {
+		chk.Config.Resolver = checker.NewResolver(config.DNSServer)
+		chk.Config.DNSClient = checker.NewDNSClient(config.DNSServer)
+	}
+	if config.VulnDBFile != "" {
+		db, err := checker.LoadVulnerabilityDB(config.VulnDBFile)
+		if err != nil {
+			log.Fatalf("Error loading vulnerability database: %v", err)
+		}
+		chk.Config.VulnerabilityDB = db
+	}
+	if config.TechRulesFile != "" {
+		rules, err := checker.LoadTechnologyRules(config.TechRulesFile)
+		if err != nil {
+			log.Fatalf("Error loading technology rules: %v", err)
+		}
+		chk.Config.TechnologyRules = rules
+	}
+	if config.TakeoverFile != "" {
+		fingerprints, err := checker.LoadTakeoverFingerprints(config.TakeoverFile)
+		if err != nil {
+			log.Fatalf("Error loading takeover fingerprints: %v", err)
+		}
+		chk.Config.TakeoverRules = fingerprints
+	}
+	profile, err := report.LoadScoringProfile(config.ScoringProfile)
+	if err != nil {
+		log.Fatalf("Error loading scoring profile: %v", err)
+	}
+	chk.Config.ScoringProfile = profile
+
+	if config.SuppressionsFile != "" {
+		suppressions, err := report.LoadSuppressions(config.SuppressionsFile)
+		if err != nil {
+			log.Fatalf("Error loading suppressions: %v", err)
+		}
+		chk.Config.Suppressions = suppressions
+	}
+
+	if config.BudgetsFile != "" {
+		budgets, err := checker.LoadPageBudgets(config.BudgetsFile)
+		if err != nil {
+			log.Fatalf("Error loading page budgets: %v", err)
+		}
+		chk.Config.PageBudgets = budgets
+	}
+
+	// Active probes stay opt-in, as in single-URL runs
+	for _, name := range config.Checkers {
+		switch name {
+		case "exposure":
+			chk.Config.ExposureScan = true
+			chk.Config.ExposurePaths = checker.DefaultExposurePaths()
+			if config.ExposurePathsFile != "" {
+				extra, err := checker.LoadExposurePaths(config.ExposurePathsFile)
+				if err != nil {
+					log.Fatalf("Error loading sensitive path list: %v", err)
+				}
+				chk.Config.ExposurePaths = append(chk.Config.ExposurePaths, extra...)
+			}
+		case "methods":
+			chk.Config.MethodProbe = true
+		}
+	}
+
+	return chk
+}
+
+// loadURLs reads one URL per line, skipping blank lines and # comments
+func loadURLs(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var urls []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, scanner.Err()
+}
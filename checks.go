@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/checkly-go/checkly/pkg/report"
+)
+
+// runChecks implements "checkly checks": it lists the catalog of built-in checks with their stable
+// IDs and severities, for writing suppression files and dashboards
+func runChecks(args []string) {
+	flags := flag.NewFlagSet("checks", flag.ExitOnError)
+	output := flags.String("output", "text", "Output format (text or json)")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s checks [options]\n\nLists the built-in checks.\n\nOptions:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	catalog := report.CheckCatalog()
+	switch *output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(catalog); err != nil {
+			log.Fatalf("Error writing check catalog: %v", err)
+		}
+	case "text":
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tSEVERITY\tNAME")
+		for _, definition := range catalog {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", definition.ID, definition.Severity, definition.Name)
+		}
+		writer.Flush()
+	default:
+		log.Fatalf("Invalid checks output format %q (valid: text, json)", *output)
+	}
+}
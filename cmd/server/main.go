@@ -18,6 +18,7 @@ import (
 	"github.com/checkly-go/checkly/internal/handlers"
 	"github.com/checkly-go/checkly/internal/storage"
 	"github.com/checkly-go/checkly/pkg/checker"
+	"github.com/checkly-go/checkly/pkg/report"
 )
 
 func main() {
@@ -71,6 +72,22 @@ func main() {
 	checkRepo := storage.NewCheckRepository(db)
 
 	chk := checker.NewChecker()
+	// SCORING_PROFILE selects the default profile: a built-in name or a JSON profile file
+	if name := os.Getenv("SCORING_PROFILE"); name != "" {
+		profile, err := report.LoadScoringProfile(name)
+		if err != nil {
+			log.Fatal("Error loading scoring profile: ", err)
+		}
+		chk.Config.ScoringProfile = profile
+	}
+	// SUPPRESSIONS_FILE lists accepted findings that submitted checks leave out of their scores
+	if file := os.Getenv("SUPPRESSIONS_FILE"); file != "" {
+		suppressions, err := report.LoadSuppressions(file)
+		if err != nil {
+			log.Fatal("Error loading suppressions: ", err)
+		}
+		chk.Config.Suppressions = suppressions
+	}
 	service := &handlers.Service{
 		Checker:   chk,
 		UserRepo:  userRepo,
@@ -83,8 +100,11 @@ func main() {
 		api.POST("/check", service.SubmitCheck)
 		api.GET("/check/:id", service.GetCheck)
 		api.GET("/check/:id/report", service.GetCheckReport)
+		api.GET("/check/:id/report.html", service.GetCheckReportHTML)
+		api.GET("/check/:id/diff/:otherId", service.GetCheckDiff)
 		api.POST("/recommend", service.GetRecommendations)
 		api.GET("/leaderboard", service.GetLeaderboard)
+		api.GET("/export", service.ExportChecks)
 		api.GET("/debug/checks", service.GetAllChecks)
 		//		api.GET("/debug/leaderboard", service.GetLeaderboardDebug)
 		api.GET("/health", func(c *gin.Context) {
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/checkly-go/checkly/pkg/checker"
 	"github.com/checkly-go/checkly/pkg/models"
+	"github.com/checkly-go/checkly/pkg/report"
 )
 
 var (
@@ -230,6 +231,12 @@ func (m model) resultsView() string {
 		return fmt.Sprintf("%s\n\nError: %v\n\nPress Enter to exit", title, m.err)
 	}
 
+	websiteReport := report.NewWebsiteReport(m.url, m.results)
+	scoreLine := fmt.Sprintf("Score: %d/100 · Grade %s", websiteReport.OverallScore, websiteReport.Grade)
+	for _, category := range websiteReport.Categories {
+		scoreLine += fmt.Sprintf(" · %s %d (%s)", category.Category, category.Score, category.Grade)
+	}
+
 	var results []string
 	for checker, checkResults := range m.results {
 		checkerTitle := fmt.Sprintf("📊 %s Results:", checker)
@@ -248,9 +255,10 @@ func (m model) resultsView() string {
 		Render("Press Enter to exit • Ctrl+C to quit")
 
 	return fmt.Sprintf(
-		"%s\n\nURL: %s\n\n%s\n\n%s",
+		"%s\n\nURL: %s\n%s\n\n%s\n\n%s",
 		title,
 		lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Render(m.url),
+		lipgloss.NewStyle().Bold(true).Render(scoreLine),
 		lipgloss.JoinVertical(lipgloss.Left, results...),
 		help,
 	)
@@ -326,7 +334,7 @@ func (m model) runChecks() tea.Cmd {
 				seoResults := checker.CheckSEOMetadataFromURL(m.url)
 				results["seo"] = seoResults
 			case "security":
-				securityResults := checker.CheckSecurityHeaders(m.url)
+				securityResults := checker.CheckSecurityHeaders(m.url, nil)
 				results["security"] = securityResults
 			}
 
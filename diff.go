@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/checkly-go/checkly/pkg/report"
+)
+
+// runDiff implements "checkly diff old.json new.json": it compares two JSON reports and prints
+// regressions, fixes, new and removed checks and the score changes
+func runDiff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	output := flags.String("output", "text", "Output format (text or json)")
+	outputFile := flags.String("o", "", "Output file path")
+	failOnRegression := flags.Bool("fail-on-regression", false, "Exit with status 1 when the score dropped or a check got worse")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [options] old.json new.json\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Compares two reports written with -output json.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if *output != "text" && *output != "json" {
+		log.Fatalf("Invalid diff output format %q (valid: text, json)", *output)
+	}
+
+	previous, err := report.LoadReport(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("Error loading %s: %v", flags.Arg(0), err)
+	}
+	current, err := report.LoadReport(flags.Arg(1))
+	if err != nil {
+		log.Fatalf("Error loading %s: %v", flags.Arg(1), err)
+	}
+
+	diff := report.DiffReports(*previous, *current)
+	if err := writeDiff(diff, *output, *outputFile); err != nil {
+		log.Fatalf("Error writing diff: %v", err)
+	}
+
+	if *failOnRegression && diff.HasRegressions() {
+		os.Exit(1)
+	}
+}
+
+// writeDiff writes the diff to stdout or to outputFile
+func writeDiff(diff report.ReportDiff, output, outputFile string) error {
+	var writer io.Writer = os.Stdout
+	if outputFile != "" {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	if output == "json" {
+		return diff.WriteJSON(writer)
+	}
+	return diff.WriteText(writer)
+}
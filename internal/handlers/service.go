@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/checkly-go/checkly/internal/storage"
 	"github.com/checkly-go/checkly/pkg/checker"
 	"github.com/checkly-go/checkly/pkg/models"
+	"github.com/checkly-go/checkly/pkg/report"
 )
 
 // Service holds the shared objects needed by the HTTP handlers.
@@ -22,28 +25,43 @@ type Service struct {
 }
 
 // SubmitCheck handles POST /api/v1/check.
-// It expects a JSON payload with a URL field.
+// It expects a JSON payload with a URL field and an optional built-in scoring profile name.
 func (s *Service) SubmitCheck(c *gin.Context) {
 	var payload struct {
-		URL string `json:"url" binding:"required"`
+		URL     string `json:"url" binding:"required"`
+		Profile string `json:"profile"`
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Only built-in profiles can be requested; profile files are a server-side setting
+	var profile *report.ScoringProfile
+	if payload.Profile != "" {
+		var err error
+		profile, err = report.BuiltinScoringProfile(payload.Profile)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "profiles": report.ScoringProfileNames()})
+			return
+		}
+	}
+
 	// Run website check (calls core checker logic)
-	report, err := s.Checker.CheckWebsite(payload.URL)
+	websiteReport, err := s.Checker.CheckWebsite(payload.URL)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check website: " + err.Error()})
 		return
 	}
+	if profile != nil {
+		profile.Apply(websiteReport)
+	}
 
 	// Construct a WebsiteCheck model instance.
 	check := &models.WebsiteCheck{
 		URL:       payload.URL,
 		Status:    "completed", // Could be an enum
-		Report:    report,
+		Report:    websiteReport,
 		CreatedAt: time.Now(),
 	}
 
@@ -73,6 +91,10 @@ func (s *Service) GetCheck(c *gin.Context) {
 		return
 	}
 
+	if check.Report != nil {
+		report.DescribeResults(check.Report.Results)
+		report.CompleteScores(check.Report)
+	}
 	c.JSON(http.StatusOK, check)
 }
 
@@ -97,9 +119,115 @@ func (s *Service) GetCheckReport(c *gin.Context) {
 		return
 	}
 
+	report.DescribeResults(check.Report.Results)
+	report.CompleteScores(check.Report)
 	c.JSON(http.StatusOK, check.Report)
 }
 
+// GetCheckReportHTML handles GET /api/v1/check/:id/report.html to download the report as a standalone HTML page.
+func (s *Service) GetCheckReportHTML(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	ctx := context.Background()
+	check, err := s.CheckRepo.GetCheck(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Check not found: " + err.Error()})
+		return
+	}
+
+	if check.Report == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Report not available"})
+		return
+	}
+
+	report.DescribeResults(check.Report.Results)
+
+	// Render into a buffer first so a template error can still produce a JSON error response
+	var buf bytes.Buffer
+	if err := report.NewHTMLReporter(&buf).WriteReport(*check.Report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render report: " + err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="report-%s.html"`, idStr))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// GetCheckDiff handles GET /api/v1/check/:id/diff/:otherId to compare two checks. :id is the earlier
+// audit and :otherId the later one; regressions are checks that got worse from the first to the second.
+func (s *Service) GetCheckDiff(c *gin.Context) {
+	ctx := context.Background()
+	var reports [2]*models.WebsiteReport
+	for i, param := range []string{"id", "otherId"} {
+		id, err := primitive.ObjectIDFromHex(c.Param(param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format: " + c.Param(param)})
+			return
+		}
+
+		check, err := s.CheckRepo.GetCheck(ctx, id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Check not found: " + err.Error()})
+			return
+		}
+		if check.Report == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not available for check " + c.Param(param)})
+			return
+		}
+		report.DescribeResults(check.Report.Results)
+		report.CompleteScores(check.Report)
+		reports[i] = check.Report
+	}
+
+	c.JSON(http.StatusOK, report.DiffReports(*reports[0], *reports[1]))
+}
+
+// ExportChecks handles GET /api/v1/export?format=csv|ndjson to download every stored report for bulk
+// analysis. Reports are streamed one at a time rather than buffered.
+func (s *Service) ExportChecks(c *gin.Context) {
+	format := c.DefaultQuery("format", "ndjson")
+
+	var reporter report.Reporter
+	switch format {
+	case "csv":
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		reporter = report.NewCSVReporter(c.Writer)
+	case "ndjson":
+		c.Header("Content-Type", "application/x-ndjson")
+		reporter = report.NewNDJSONReporter(c.Writer)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported format, use csv or ndjson"})
+		return
+	}
+
+	ctx := context.Background()
+	checks, err := s.CheckRepo.GetAllChecks(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch checks: " + err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="checks-%s.%s"`, time.Now().Format("20060102"), format))
+	c.Status(http.StatusOK)
+	for _, check := range checks {
+		if check.Report == nil {
+			continue
+		}
+		report.DescribeResults(check.Report.Results)
+		report.CompleteScores(check.Report)
+		// Headers are already sent, so a failed write can only end the stream
+		if err := reporter.WriteReport(*check.Report); err != nil {
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
 // GetLeaderboard handles GET /api/v1/leaderboard to retrieve top websites by score.
 func (s *Service) GetLeaderboard(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "10")
@@ -118,6 +246,13 @@ func (s *Service) GetLeaderboard(c *gin.Context) {
 		return
 	}
 
+	// Reports stored before grades were recorded are graded from their score
+	for i := range leaderboard {
+		if leaderboard[i].Grade == "" {
+			leaderboard[i].Grade = report.Grade(leaderboard[i].OverallScore)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"leaderboard": leaderboard,
 		"total":       len(leaderboard),
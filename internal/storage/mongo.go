@@ -88,6 +88,7 @@ func (r *checkRepository) GetLeaderboard(ctx context.Context, limit int) ([]mode
 				"_id":          "$url",
 				"url":          bson.M{"$first": "$url"},
 				"overallscore": bson.M{"$first": "$report.overallscore"},
+				"grade":        bson.M{"$first": "$report.grade"},
 				"timestamp":    bson.M{"$first": "$created_at"},
 			},
 		},
@@ -104,6 +105,7 @@ func (r *checkRepository) GetLeaderboard(ctx context.Context, limit int) ([]mode
 			"$project": bson.M{
 				"url":          1,
 				"overallscore": 1,
+				"grade":        1,
 				"timestamp":    1,
 				"_id":          0,
 			},
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/checkly-go/checkly/pkg/checker"
 	"github.com/checkly-go/checkly/pkg/models"
@@ -19,25 +20,105 @@ type Config struct {
 	Checkers   []string
 	Output     string
 	OutputFile string
+	APIPaths   []string
+
+	ExposurePathsFile string
+	ProbeDelay        time.Duration
+	VulnDBFile        string
+	TechRulesFile     string
+	TakeoverFile      string
+	DNSServer         string
+	BudgetsFile       string
+	BaselineFile      string
+	ScoringProfile    string
+	JUnitWarnings     string
+	SuppressionsFile  string
+	FailOn            string
+	URLsFile          string
+	Concurrency       int
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "checks" {
+		runChecks(os.Args[2:])
+		return
+	}
+
 	config := parseFlags()
 
+	if config.URLsFile != "" {
+		if runBulk(config) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if config.URL == "" {
 		fmt.Println("Error: URL is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	// Load the baseline up front so a bad path fails before the checks run
+	var baseline *models.WebsiteReport
+	if config.BaselineFile != "" {
+		loaded, err := report.LoadReport(config.BaselineFile)
+		if err != nil {
+			log.Fatalf("Error loading baseline report: %v", err)
+		}
+		baseline = loaded
+	}
+
+	profile, err := report.LoadScoringProfile(config.ScoringProfile)
+	if err != nil {
+		log.Fatalf("Error loading scoring profile: %v", err)
+	}
+
+	var suppressions *report.SuppressionList
+	if config.SuppressionsFile != "" {
+		suppressions, err = report.LoadSuppressions(config.SuppressionsFile)
+		if err != nil {
+			log.Fatalf("Error loading suppressions: %v", err)
+		}
+	}
+
+	// Fingerprinting rules are shared by the tech checker and the security header checks
+	var techRules []checker.TechnologyRule
+	if config.TechRulesFile != "" {
+		techRules, err = checker.LoadTechnologyRules(config.TechRulesFile)
+		if err != nil {
+			log.Fatalf("Error loading technology rules: %v", err)
+		}
+	}
+
+	var takeoverRules []checker.TakeoverFingerprint
+	if config.TakeoverFile != "" {
+		takeoverRules, err = checker.LoadTakeoverFingerprints(config.TakeoverFile)
+		if err != nil {
+			log.Fatalf("Error loading takeover fingerprints: %v", err)
+		}
+	}
+
 	fmt.Printf("Website Checker - Analyzing: %s\n", config.URL)
 	fmt.Println("=========================================")
 
 	// Collect all results by category
 	allResults := make(map[string][]models.CheckResult)
+	var technologies []models.Technology
+	var timing *models.Timing
 
 	// Run checkers based on flags
 	for _, checkerName := range config.Checkers {
+		checkStart := time.Now()
+		counts := make(map[string]int)
+		for category, results := range allResults {
+			counts[category] = len(results)
+		}
+
 		switch checkerName {
 		case "robots":
 			result := checker.CheckRobotsTxt(config.URL)
@@ -48,6 +129,15 @@ func main() {
 				printTextResult(result)
 			}
 
+		case "securitytxt":
+			result := checker.CheckSecurityTxt(config.URL)
+			allResults["securitytxt"] = []models.CheckResult{result}
+			if config.Output == "text" {
+				fmt.Println("\n🔐 Security.txt Check:")
+				fmt.Println("----------------------")
+				printTextResult(result)
+			}
+
 		case "sitemap":
 			result := checker.CheckSitemapWithRobotsURL(config.URL)
 			allResults["sitemap"] = []models.CheckResult{result}
@@ -69,9 +159,22 @@ func main() {
 				}
 			}
 
+		case "accessibility":
+			results := checker.CheckAccessibilityFromURL(config.URL)
+			allResults["accessibility"] = results
+			if config.Output == "text" {
+				fmt.Println("\n♿ Accessibility Checks:")
+				fmt.Println("------------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
 		case "security":
-			results := checker.CheckSecurityHeaders(config.URL)
-			allResults["security"] = results
+			results := checker.CheckSecurityHeaders(config.URL, techRules)
+			results = append(results, checker.CheckHSTSPreload(config.URL))
+			allResults["security"] = append(allResults["security"], results...)
 			if config.Output == "text" {
 				fmt.Println("\n🛡️  Security Headers Checks:")
 				fmt.Println("----------------------------")
@@ -80,43 +183,287 @@ func main() {
 					fmt.Println()
 				}
 			}
-		}
-	}
 
-	// Output results
-	if config.Output == "json" {
-		var writer io.Writer = os.Stdout
+		case "cors":
+			results := checker.CheckCORS(config.URL, config.APIPaths)
+			allResults["security"] = append(allResults["security"], results...)
+			if config.Output == "text" {
+				fmt.Println("\n🌍 CORS Checks:")
+				fmt.Println("---------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "jslibs":
+			var db *checker.VulnerabilityDB
+			if config.VulnDBFile != "" {
+				loaded, err := checker.LoadVulnerabilityDB(config.VulnDBFile)
+				if err != nil {
+					log.Fatalf("Error loading vulnerability database: %v", err)
+				}
+				db = loaded
+			}
+			results := checker.CheckJSLibrariesFromURL(config.URL, db)
+			allResults["security"] = append(allResults["security"], results...)
+			if config.Output == "text" {
+				fmt.Println("\n📦 JavaScript Library Checks:")
+				fmt.Println("-----------------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
 
-		// If output file is specified, create the file
-		if config.OutputFile != "" {
-			file, err := os.Create(config.OutputFile)
+		case "exposure":
+			paths := checker.DefaultExposurePaths()
+			if config.ExposurePathsFile != "" {
+				extra, err := checker.LoadExposurePaths(config.ExposurePathsFile)
+				if err != nil {
+					log.Fatalf("Error loading sensitive path list: %v", err)
+				}
+				paths = append(paths, extra...)
+			}
+			results := checker.CheckSensitiveFiles(config.URL, paths, config.ProbeDelay)
+			allResults["security"] = append(allResults["security"], results...)
+			if config.Output == "text" {
+				fmt.Println("\n🔍 Sensitive File Exposure Checks:")
+				fmt.Println("----------------------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "methods":
+			results := checker.CheckHTTPMethods(config.URL, config.ProbeDelay)
+			allResults["security"] = append(allResults["security"], results...)
+			if config.Output == "text" {
+				fmt.Println("\n🧪 HTTP Method Probes:")
+				fmt.Println("----------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "email":
+			results := checker.CheckEmailSecurity(config.URL, checker.NewResolver(config.DNSServer))
+			allResults["email"] = results
+			if config.Output == "text" {
+				fmt.Println("\n📧 Email Security Checks:")
+				fmt.Println("-------------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "performance":
+			measured, results := checker.CheckResponseTiming(config.URL)
+			timing = measured
+			var budgets *checker.PageBudgets
+			if config.BudgetsFile != "" {
+				loaded, err := checker.LoadPageBudgets(config.BudgetsFile)
+				if err != nil {
+					log.Fatalf("Error loading page budgets: %v", err)
+				}
+				budgets = loaded
+			}
+			if page, err := checker.FetchPage(config.URL); err == nil && page.StatusCode < 400 {
+				results = append(results, checker.CheckCompression(page)...)
+				results = append(results, checker.CheckCaching(page)...)
+				results = append(results, checker.CheckHTTPProtocol(page)...)
+				results = append(results, checker.CheckPageWeight(page.URL, page.Body, budgets)...)
+			}
+			allResults["performance"] = results
+			if config.Output == "text" {
+				fmt.Println("\n⏱️  Performance Checks:")
+				fmt.Println("----------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "dns":
+			results := checker.CheckDNSHygiene(config.URL, checker.NewDNSClient(config.DNSServer), takeoverRules)
+			allResults["dns"] = results
+			if config.Output == "text" {
+				fmt.Println("\n🧭 DNS Hygiene Checks:")
+				fmt.Println("----------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
+			}
+
+		case "tech":
+			detected, err := checker.DetectTechnologiesFromURL(config.URL, techRules)
 			if err != nil {
-				log.Fatalf("Error creating output file: %v", err)
+				log.Printf("Error fingerprinting technologies: %v", err)
+			}
+			technologies = detected
+			if config.Output == "text" {
+				fmt.Println("\n🧬 Detected Technologies:")
+				fmt.Println("-------------------------")
+				if len(detected) == 0 {
+					fmt.Println("No technologies detected")
+				}
+				for _, tech := range detected {
+					printTechnology(tech)
+				}
+			}
+
+		case "sri":
+			results := checker.CheckSubresourceIntegrityFromURL(config.URL)
+			allResults["security"] = append(allResults["security"], results...)
+			if config.Output == "text" {
+				fmt.Println("\n🔗 Subresource Integrity Checks:")
+				fmt.Println("--------------------------------")
+				for _, result := range results {
+					printTextResult(result)
+					fmt.Println()
+				}
 			}
-			defer file.Close()
-			writer = file
-			fmt.Printf("Writing JSON report to: %s\n", config.OutputFile)
 		}
 
-		jsonReporter := report.NewJSONReporter(writer, true)
-		err := jsonReporter.GenerateReport(config.URL, allResults)
-		if err != nil {
-			log.Printf("Error generating JSON report: %v", err)
+		// Attribute the time this checker took to the results it added
+		elapsed := time.Since(checkStart)
+		for category, results := range allResults {
+			if len(results) > counts[category] {
+				checker.SplitDuration(results[counts[category]:], elapsed)
+			}
 		}
+	}
+
+	websiteReport := report.NewWebsiteReport(config.URL, allResults)
+	websiteReport.Technologies = technologies
+	websiteReport.Timing = timing
+	if suppressions != nil {
+		suppressions.Apply(&websiteReport, time.Now())
+	}
+	profile.Apply(&websiteReport)
+	for _, warning := range websiteReport.SuppressionWarnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	// Output results
+	if config.Output == "text" {
+		printScoreSummary(websiteReport)
+	} else {
+		writeReport(config, websiteReport, baseline)
+	}
 
-		// If writing to file, also show success message
-		if config.OutputFile != "" {
-			fmt.Printf("JSON report generated successfully!\n")
+	if failsOn(config.FailOn, websiteReport) {
+		os.Exit(1)
+	}
+}
+
+// writeReport writes the report in the selected output format to stdout or the output file
+func writeReport(config Config, websiteReport models.WebsiteReport, baseline *models.WebsiteReport) {
+	format := strings.ToUpper(config.Output)
+	var writer io.Writer = os.Stdout
+
+	// If output file is specified, create the file
+	if config.OutputFile != "" {
+		file, err := os.Create(config.OutputFile)
+		if err != nil {
+			log.Fatalf("Error creating output file: %v", err)
 		}
+		defer file.Close()
+		writer = file
+		fmt.Printf("Writing %s report to: %s\n", format, config.OutputFile)
+	}
+
+	var reporter report.Reporter
+	switch config.Output {
+	case "html":
+		reporter = report.NewHTMLReporter(writer)
+	case "markdown":
+		reporter = report.NewMarkdownReporter(writer, baseline)
+	case "sarif":
+		reporter = report.NewSARIFReporter(writer)
+	case "junit":
+		reporter = report.NewJUnitReporter(writer, config.JUnitWarnings == "failure")
+	case "csv":
+		reporter = report.NewCSVReporter(writer)
+	case "ndjson":
+		reporter = report.NewNDJSONReporter(writer)
+	default:
+		reporter = report.NewJSONReporter(writer, true)
+	}
+	if err := reporter.WriteReport(websiteReport); err != nil {
+		log.Printf("Error generating %s report: %v", format, err)
+	}
+
+	// If writing to file, also show success message
+	if config.OutputFile != "" {
+		fmt.Printf("%s report generated successfully!\n", format)
+	}
+}
+
+// failsOn reports whether the -fail-on level calls for a non-zero exit: "warning" for any warning
+// or failure, "fail" for any failure, "none" never. Suppressed findings don't count.
+func failsOn(level string, websiteReport models.WebsiteReport) bool {
+	switch level {
+	case "warning":
+		return report.HasFindings(websiteReport, models.StatusWarning)
+	case "fail":
+		return report.HasFindings(websiteReport, models.StatusFail)
+	default:
+		return false
 	}
 }
 
 func printTextResult(result models.CheckResult) {
+	result = report.DescribeResult(result)
 	statusEmoji := getStatusEmoji(result.Status)
 	fmt.Printf("%s %s: %s\n", statusEmoji, result.Name, result.Message)
 	if result.Details != "" {
 		fmt.Printf("   Details: %s\n", result.Details)
 	}
+	if result.Remediation != "" {
+		fmt.Printf("   Fix (%s, %s): %s\n", result.Severity, result.ID, result.Remediation)
+	}
+}
+
+// printScoreSummary prints the overall and per-category scores with their grades
+func printScoreSummary(websiteReport models.WebsiteReport) {
+	fmt.Println("\n📊 Score:")
+	fmt.Println("---------")
+	fmt.Printf("Overall: %d/100 (grade %s, profile %s)\n", websiteReport.OverallScore, websiteReport.Grade, websiteReport.ScoringProfile)
+	fmt.Printf("Results: %d passed, %d warnings, %d failed", websiteReport.Passed, websiteReport.Warnings, websiteReport.Failed)
+	if websiteReport.Suppressed > 0 {
+		fmt.Printf(", %d suppressed", websiteReport.Suppressed)
+	}
+	fmt.Println()
+	for _, category := range websiteReport.Categories {
+		fmt.Printf("   %-14s %3d  %-2s  (%d passed, %d warnings, %d failed", category.Category, category.Score, category.Grade, category.Passed, category.Warnings, category.Failed)
+		if category.Suppressed > 0 {
+			fmt.Printf(", %d suppressed", category.Suppressed)
+		}
+		fmt.Println(")")
+	}
+
+	if websiteReport.Suppressed > 0 {
+		fmt.Println("\n🔕 Suppressed findings:")
+		for _, result := range websiteReport.Results {
+			if result.Suppressed {
+				fmt.Printf("%s %s: %s\n   Reason: %s\n", getStatusEmoji(result.Status), result.Name, result.Message, result.SuppressionReason)
+			}
+		}
+	}
+}
+
+func printTechnology(tech models.Technology) {
+	name := tech.Name
+	if tech.Version != "" {
+		name += " " + tech.Version
+	}
+	fmt.Printf("🔹 %s (%s)\n", name, tech.Category)
+	fmt.Printf("   Detected via: %s\n", strings.Join(tech.Sources, ", "))
 }
 
 func getStatusEmoji(status models.Status) string {
@@ -138,16 +485,34 @@ func parseFlags() Config {
 
 	flag.StringVar(&config.URL, "url", "", "URL to check (required)")
 	flag.StringVar(&config.URL, "link", "", "URL to check (alias for -url)")
+	flag.StringVar(&config.URLsFile, "urls", "", "File with one URL per line to audit in bulk (requires -output csv or ndjson)")
+	flag.IntVar(&config.Concurrency, "concurrency", 4, "Number of URLs audited at once in bulk mode")
 	flag.BoolVar(&tuiMode, "tui", false, "Run in TUI mode (interactive terminal UI)")
 
 	var checkersFlag string
-	flag.StringVar(&checkersFlag, "checkers", "robots,sitemap,seo,security", "Comma-separated list of checkers to run (robots,sitemap,seo,security)")
-
-	flag.StringVar(&config.Output, "output", "text", "Output format (text or json)")
-	flag.StringVar(&config.OutputFile, "o", "", "Output file path (for JSON reports)")
+	flag.StringVar(&checkersFlag, "checkers", "robots,securitytxt,sitemap,seo,accessibility,security,sri,jslibs,cors,email,dns,performance,tech", "Comma-separated list of checkers to run (robots,securitytxt,sitemap,seo,accessibility,security,sri,jslibs,cors,email,dns,performance,tech; opt-in: exposure,methods)")
+
+	var apiPathsFlag string
+	flag.StringVar(&apiPathsFlag, "api-paths", "", "Comma-separated API paths to include in the CORS probe (e.g. /api/me,/api/v1/orders)")
+
+	flag.StringVar(&config.VulnDBFile, "vulndb", "", "JSON vulnerability database replacing the embedded one for the jslibs checker")
+	flag.StringVar(&config.DNSServer, "dns-server", "", "DNS server (host:port) used by the email and dns checkers instead of the system resolver")
+	flag.StringVar(&config.TechRulesFile, "tech-rules", "", "JSON fingerprinting rules replacing the embedded ones for the tech and security checkers")
+	flag.StringVar(&config.TakeoverFile, "takeover-fingerprints", "", "JSON subdomain takeover fingerprints replacing the embedded ones for the dns checker")
+	flag.StringVar(&config.BudgetsFile, "budgets", "", "JSON page weight budgets for the performance checker (missing limits keep their defaults)")
+	flag.StringVar(&config.ExposurePathsFile, "exposure-paths", "", "JSON file with extra paths for the exposure checker")
+	flag.DurationVar(&config.ProbeDelay, "probe-delay", 250*time.Millisecond, "Delay between requests sent by active probes")
+
+	flag.StringVar(&config.Output, "output", "text", "Output format (text, json, html, markdown, sarif, junit, csv or ndjson)")
+	flag.StringVar(&config.ScoringProfile, "profile", report.DefaultProfileName, "Scoring profile: a built-in name ("+strings.Join(report.ScoringProfileNames(), ", ")+") or a JSON profile file")
+	flag.StringVar(&config.BaselineFile, "baseline", "", "Earlier JSON report to show score deltas against in Markdown output")
+	flag.StringVar(&config.JUnitWarnings, "junit-warnings", "skipped", "How JUnit output reports warnings (skipped or failure)")
+	flag.StringVar(&config.SuppressionsFile, "suppressions", "", "JSON file of accepted findings, left out of the score and -fail-on")
+	flag.StringVar(&config.FailOn, "fail-on", "none", "Exit with status 1 on findings at this level or worse (none, warning or fail)")
+	flag.StringVar(&config.OutputFile, "o", "", "Output file path (for all formats except text)")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n       %s diff [options] old.json new.json\n       %s checks [options]\n\n", os.Args[0], os.Args[0], os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
@@ -156,6 +521,10 @@ func parseFlags() Config {
 		fmt.Fprintf(os.Stderr, "  %s -link https://example.com -checkers robots,seo -output json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -url https://example.com -output json -o report.json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -url https://example.com -checkers security -output text\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -url https://example.com -checkers exposure,methods -probe-delay 500ms\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -urls sites.txt -output csv -o audit.csv -concurrency 8\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s diff last-week.json today.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s checks\n", os.Args[0])
 	}
 
 	flag.Parse()
@@ -174,12 +543,32 @@ func parseFlags() Config {
 		}
 	}
 
+	// Parse API paths for the CORS probe
+	if apiPathsFlag != "" {
+		for _, path := range strings.Split(apiPathsFlag, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				config.APIPaths = append(config.APIPaths, path)
+			}
+		}
+	}
+
 	// Validate checkers
 	validCheckers := map[string]bool{
-		"robots":   true,
-		"sitemap":  true,
-		"seo":      true,
-		"security": true,
+		"robots":        true,
+		"securitytxt":   true,
+		"sitemap":       true,
+		"seo":           true,
+		"accessibility": true,
+		"security":      true,
+		"sri":           true,
+		"jslibs":        true,
+		"cors":          true,
+		"exposure":      true,
+		"methods":       true,
+		"email":         true,
+		"dns":           true,
+		"performance":   true,
+		"tech":          true,
 	}
 
 	var filteredCheckers []string
@@ -193,14 +582,25 @@ func parseFlags() Config {
 	config.Checkers = filteredCheckers
 
 	// Validate output format
-	if config.Output != "text" && config.Output != "json" {
+	validOutputs := map[string]bool{"text": true, "json": true, "html": true, "markdown": true, "sarif": true, "junit": true, "csv": true, "ndjson": true}
+	if !validOutputs[config.Output] {
 		fmt.Printf("Warning: Unknown output format '%s', defaulting to 'text'\n", config.Output)
 		config.Output = "text"
 	}
 
+	if config.JUnitWarnings != "skipped" && config.JUnitWarnings != "failure" {
+		fmt.Printf("Warning: Unknown -junit-warnings value '%s', defaulting to 'skipped'\n", config.JUnitWarnings)
+		config.JUnitWarnings = "skipped"
+	}
+
+	// An unknown -fail-on level must not silently let a CI gate pass
+	if config.FailOn != "none" && config.FailOn != "warning" && config.FailOn != "fail" {
+		log.Fatalf("Invalid -fail-on value '%s' (valid: none, warning, fail)", config.FailOn)
+	}
+
 	// Validate output file usage
-	if config.OutputFile != "" && config.Output != "json" {
-		fmt.Println("Warning: Output file (-o) only supported with JSON output format. Setting output to 'json'.")
+	if config.OutputFile != "" && config.Output == "text" {
+		fmt.Println("Warning: Output file (-o) is not supported with text output. Setting output to 'json'.")
 		config.Output = "json"
 	}
 
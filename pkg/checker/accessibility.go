@@ -0,0 +1,1098 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// maxReportedElements caps how many offending elements are listed in a result's details
+const maxReportedElements = 5
+
+// WCAG success criteria referenced by the accessibility checks
+const (
+	wcagInfoAndRelationships = "1.3.1 Info and Relationships"
+	wcagContrastMinimum      = "1.4.3 Contrast (Minimum)"
+	wcagBypassBlocks         = "2.4.1 Bypass Blocks"
+	wcagFocusOrder           = "2.4.3 Focus Order"
+	wcagLinkPurpose          = "2.4.4 Link Purpose (In Context)"
+	wcagParsing              = "4.1.1 Parsing"
+	wcagNameRoleValue        = "4.1.2 Name, Role, Value"
+)
+
+// ariaRoles are the roles defined by WAI-ARIA 1.2; DPUB (doc-*) and graphics (graphics-*) roles are accepted by prefix
+var ariaRoles = map[string]bool{
+	"alert": true, "alertdialog": true, "application": true, "article": true, "banner": true,
+	"blockquote": true, "button": true, "caption": true, "cell": true, "checkbox": true, "code": true,
+	"columnheader": true, "combobox": true, "complementary": true, "contentinfo": true,
+	"definition": true, "deletion": true, "dialog": true, "directory": true, "document": true,
+	"emphasis": true, "feed": true, "figure": true, "form": true, "generic": true, "grid": true,
+	"gridcell": true, "group": true, "heading": true, "img": true, "insertion": true, "link": true,
+	"list": true, "listbox": true, "listitem": true, "log": true, "main": true, "marquee": true,
+	"math": true, "menu": true, "menubar": true, "menuitem": true, "menuitemcheckbox": true,
+	"menuitemradio": true, "meter": true, "navigation": true, "none": true, "note": true,
+	"option": true, "paragraph": true, "presentation": true, "progressbar": true, "radio": true,
+	"radiogroup": true, "region": true, "row": true, "rowgroup": true, "rowheader": true,
+	"scrollbar": true, "search": true, "searchbox": true, "separator": true, "slider": true,
+	"spinbutton": true, "status": true, "strong": true, "subscript": true, "superscript": true,
+	"switch": true, "tab": true, "table": true, "tablist": true, "tabpanel": true, "term": true,
+	"textbox": true, "time": true, "timer": true, "toolbar": true, "tooltip": true, "tree": true,
+	"treegrid": true, "treeitem": true,
+}
+
+// ariaAttributes are the states and properties defined by WAI-ARIA 1.2, without the aria- prefix
+var ariaAttributes = map[string]bool{
+	"activedescendant": true, "atomic": true, "autocomplete": true, "braillelabel": true,
+	"brailleroledescription": true, "busy": true, "checked": true, "colcount": true,
+	"colindex": true, "colindextext": true, "colspan": true, "controls": true, "current": true,
+	"describedby": true, "description": true, "details": true, "disabled": true,
+	"dropeffect": true, "errormessage": true, "expanded": true, "flowto": true, "grabbed": true,
+	"haspopup": true, "hidden": true, "invalid": true, "keyshortcuts": true, "label": true,
+	"labelledby": true, "level": true, "live": true, "modal": true, "multiline": true,
+	"multiselectable": true, "orientation": true, "owns": true, "placeholder": true,
+	"posinset": true, "pressed": true, "readonly": true, "relevant": true, "required": true,
+	"roledescription": true, "rowcount": true, "rowindex": true, "rowindextext": true,
+	"rowspan": true, "selected": true, "setsize": true, "sort": true, "valuemax": true,
+	"valuemin": true, "valuenow": true, "valuetext": true,
+}
+
+// ariaTokenValues lists the allowed values of enumerated ARIA attributes
+var ariaTokenValues = map[string][]string{
+	"aria-atomic":      {"true", "false"},
+	"aria-busy":        {"true", "false"},
+	"aria-checked":     {"true", "false", "mixed", "undefined"},
+	"aria-current":     {"page", "step", "location", "date", "time", "true", "false"},
+	"aria-disabled":    {"true", "false"},
+	"aria-expanded":    {"true", "false", "undefined"},
+	"aria-haspopup":    {"true", "false", "menu", "listbox", "tree", "grid", "dialog"},
+	"aria-hidden":      {"true", "false", "undefined"},
+	"aria-invalid":     {"true", "false", "grammar", "spelling"},
+	"aria-live":        {"off", "polite", "assertive"},
+	"aria-modal":       {"true", "false"},
+	"aria-multiline":   {"true", "false"},
+	"aria-orientation": {"horizontal", "vertical", "undefined"},
+	"aria-pressed":     {"true", "false", "mixed", "undefined"},
+	"aria-readonly":    {"true", "false"},
+	"aria-required":    {"true", "false"},
+	"aria-selected":    {"true", "false", "undefined"},
+	"aria-sort":        {"ascending", "descending", "none", "other"},
+}
+
+// ariaIDReferences are the attributes whose value is a list of element IDs
+var ariaIDReferences = []string{
+	"aria-activedescendant", "aria-controls", "aria-describedby", "aria-details",
+	"aria-errormessage", "aria-flowto", "aria-labelledby", "aria-owns",
+}
+
+// cssNamedColors covers the basic CSS color keywords
+var cssNamedColors = map[string][3]float64{
+	"black": {0, 0, 0}, "white": {255, 255, 255}, "red": {255, 0, 0}, "green": {0, 128, 0},
+	"blue": {0, 0, 255}, "yellow": {255, 255, 0}, "orange": {255, 165, 0}, "purple": {128, 0, 128},
+	"gray": {128, 128, 128}, "grey": {128, 128, 128}, "silver": {192, 192, 192},
+	"darkgray": {169, 169, 169}, "darkgrey": {169, 169, 169}, "lightgray": {211, 211, 211},
+	"lightgrey": {211, 211, 211}, "maroon": {128, 0, 0}, "navy": {0, 0, 128}, "teal": {0, 128, 128},
+	"olive": {128, 128, 0}, "lime": {0, 255, 0}, "aqua": {0, 255, 255}, "cyan": {0, 255, 255},
+	"fuchsia": {255, 0, 255}, "magenta": {255, 0, 255},
+}
+
+// ContrastIssue records text whose inline colors fail the WCAG AA contrast ratio
+type ContrastIssue struct {
+	Element    string  `json:"element"`
+	Foreground string  `json:"foreground"`
+	Background string  `json:"background"`
+	Ratio      float64 `json:"ratio"`
+	Required   float64 `json:"required"`
+}
+
+// accessibilityAudit collects the findings of a single pass over the document
+type accessibilityAudit struct {
+	ids         map[string]*html.Node
+	idCounts    map[string]int
+	labelFor    map[string]bool
+	referenced  map[string]bool // IDs used by label[for] or ARIA references
+	controls    int
+	unlabeled   []string
+	unnamed     []string // Buttons without an accessible name
+	vagueLinks  []string // Links without an accessible name
+	badRoles    []string
+	badAttrs    []string
+	brokenRefs  []string
+	landmarks   map[string]int
+	positiveTab []string
+	frames      int
+	untitled    []string
+	contrast    []ContrastIssue
+	colorPairs  int
+}
+
+// CheckAccessibility runs static, DOM-based accessibility checks and maps each to its WCAG success criterion.
+// Styles from stylesheets are not evaluated, so contrast is only checked where colors are set inline.
+func CheckAccessibility(htmlContent string) []models.CheckResult {
+	start := time.Now()
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Accessibility",
+			Status:    models.StatusFail,
+			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	audit := newAccessibilityAudit(doc)
+
+	return []models.CheckResult{
+		checkFormLabels(audit, start),
+		checkAccessibleNames(audit, start),
+		checkARIAUsage(audit, start),
+		checkLandmarks(audit, start),
+		checkDuplicateIDs(audit, start),
+		checkTabindex(audit, start),
+		checkFrameTitles(audit, start),
+		checkColorContrast(audit, start),
+	}
+}
+
+// CheckAccessibilityFromURL fetches HTML content from URL and audits its accessibility
+func CheckAccessibilityFromURL(pageURL string) []models.CheckResult {
+	start := time.Now()
+
+	htmlContent, _, err := fetchHTML(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Accessibility",
+			Status:    models.StatusFail,
+			Message:   "Failed to fetch page",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	return CheckAccessibility(htmlContent)
+}
+
+// newAccessibilityAudit indexes IDs and labels, then walks the document applying every rule
+func newAccessibilityAudit(doc *html.Node) *accessibilityAudit {
+	audit := &accessibilityAudit{
+		ids:        make(map[string]*html.Node),
+		idCounts:   make(map[string]int),
+		labelFor:   make(map[string]bool),
+		referenced: make(map[string]bool),
+		landmarks:  make(map[string]int),
+	}
+
+	// IDs must be known before names can be computed from aria-labelledby
+	walkElements(doc, func(n *html.Node) bool {
+		if id := strings.TrimSpace(attrValue(n, "id")); id != "" {
+			audit.idCounts[id]++
+			if audit.ids[id] == nil {
+				audit.ids[id] = n
+			}
+		}
+		if n.Data == "label" {
+			if target := strings.TrimSpace(attrValue(n, "for")); target != "" {
+				audit.labelFor[target] = true
+				audit.referenced[target] = true
+			}
+		}
+		for _, name := range ariaIDReferences {
+			for _, id := range strings.Fields(attrValue(n, name)) {
+				audit.referenced[id] = true
+			}
+		}
+		return true
+	})
+
+	var visit func(n *html.Node, inLabel bool)
+	visit = func(n *html.Node, inLabel bool) {
+		if n.Type == html.ElementNode {
+			if n.Data == "template" {
+				return
+			}
+			if isHiddenElement(n) {
+				// Hidden content is out of the accessibility tree; its IDs were indexed above
+				return
+			}
+			audit.inspect(n, inLabel)
+			if n.Data == "label" {
+				inLabel = true
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c, inLabel)
+		}
+	}
+	visit(doc, false)
+
+	return audit
+}
+
+// inspect applies the per-element rules to n
+func (a *accessibilityAudit) inspect(n *html.Node, inLabel bool) {
+	role := strings.ToLower(strings.TrimSpace(attrValue(n, "role")))
+
+	switch n.Data {
+	case "input", "select", "textarea":
+		inputType := strings.ToLower(attrValue(n, "type"))
+		switch {
+		case n.Data == "input" && (inputType == "submit" || inputType == "reset" || inputType == "button" || inputType == "image"):
+			if a.accessibleName(n) == "" {
+				a.unnamed = append(a.unnamed, describeElement(n))
+			}
+		case n.Data == "input" && inputType == "hidden":
+		default:
+			a.controls++
+			if !inLabel && !a.hasLabel(n) {
+				a.unlabeled = append(a.unlabeled, describeElement(n))
+			}
+		}
+	case "button":
+		if a.accessibleName(n) == "" {
+			a.unnamed = append(a.unnamed, describeElement(n))
+		}
+	case "a":
+		if hasAttr(n, "href") && a.accessibleName(n) == "" {
+			a.vagueLinks = append(a.vagueLinks, describeElement(n))
+		}
+	case "iframe":
+		a.frames++
+		if strings.TrimSpace(attrValue(n, "title")) == "" && strings.TrimSpace(attrValue(n, "aria-label")) == "" && a.labelledByText(n) == "" {
+			a.untitled = append(a.untitled, describeElement(n))
+		}
+	}
+
+	if role == "button" || role == "link" {
+		if n.Data != "button" && n.Data != "a" && a.accessibleName(n) == "" {
+			if role == "link" {
+				a.vagueLinks = append(a.vagueLinks, describeElement(n))
+			} else {
+				a.unnamed = append(a.unnamed, describeElement(n))
+			}
+		}
+	}
+
+	a.inspectARIA(n, role)
+	a.countLandmark(n, role)
+
+	if value, ok := attrLookup(n, "tabindex"); ok {
+		if index, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && index > 0 {
+			a.positiveTab = append(a.positiveTab, describeElement(n))
+		}
+	}
+
+	a.inspectContrast(n)
+}
+
+// inspectARIA validates the role and aria-* attributes of n
+func (a *accessibilityAudit) inspectARIA(n *html.Node, role string) {
+	for _, token := range strings.Fields(role) {
+		if !ariaRoles[token] && !strings.HasPrefix(token, "doc-") && !strings.HasPrefix(token, "graphics-") {
+			a.badRoles = append(a.badRoles, fmt.Sprintf("%s (role=%q)", describeElement(n), token))
+		}
+	}
+
+	for _, attr := range n.Attr {
+		key := strings.ToLower(attr.Key)
+		if !strings.HasPrefix(key, "aria-") {
+			continue
+		}
+		if !ariaAttributes[strings.TrimPrefix(key, "aria-")] {
+			a.badAttrs = append(a.badAttrs, fmt.Sprintf("%s (unknown attribute %s)", describeElement(n), key))
+			continue
+		}
+		if allowed, ok := ariaTokenValues[key]; ok {
+			value := strings.ToLower(strings.TrimSpace(attr.Val))
+			if !containsString(allowed, value) {
+				a.badAttrs = append(a.badAttrs, fmt.Sprintf("%s (%s=%q, expected %s)", describeElement(n), key, attr.Val, strings.Join(allowed, "/")))
+			}
+		}
+	}
+
+	for _, name := range ariaIDReferences {
+		for _, id := range strings.Fields(attrValue(n, name)) {
+			if a.idCounts[id] == 0 {
+				a.brokenRefs = append(a.brokenRefs, fmt.Sprintf("%s (%s references missing #%s)", describeElement(n), name, id))
+			}
+		}
+	}
+}
+
+// countLandmark records landmark regions by their ARIA role
+func (a *accessibilityAudit) countLandmark(n *html.Node, role string) {
+	landmark := role
+	if landmark == "" {
+		switch n.Data {
+		case "main":
+			landmark = "main"
+		case "nav":
+			landmark = "navigation"
+		case "aside":
+			landmark = "complementary"
+		case "search":
+			landmark = "search"
+		case "header":
+			// header and footer are only landmarks when scoped to the body
+			if !hasSectioningAncestor(n) {
+				landmark = "banner"
+			}
+		case "footer":
+			if !hasSectioningAncestor(n) {
+				landmark = "contentinfo"
+			}
+		}
+	}
+
+	switch landmark {
+	case "main", "navigation", "banner", "contentinfo", "complementary", "search", "region", "form":
+		a.landmarks[landmark]++
+	}
+}
+
+// inspectContrast checks text colors where the element itself declares an inline color or background
+func (a *accessibilityAudit) inspectContrast(n *html.Node) {
+	style := parseInlineStyle(attrValue(n, "style"))
+	_, hasColor := style["color"]
+	_, hasBackground := inlineBackground(style)
+	if (!hasColor && !hasBackground) || !hasTextContent(n) {
+		return
+	}
+
+	fgValue, fg, ok := inheritedColor(n, func(s map[string]string) (string, bool) {
+		v, ok := s["color"]
+		return v, ok
+	})
+	if !ok {
+		return
+	}
+	bgValue, bg, ok := inheritedColor(n, inlineBackground)
+	if !ok {
+		return
+	}
+
+	a.colorPairs++
+	ratio := contrastRatio(fg, bg)
+	required := 4.5
+	if isLargeText(n) {
+		required = 3
+	}
+	if ratio < required {
+		a.contrast = append(a.contrast, ContrastIssue{
+			Element:    describeElement(n),
+			Foreground: fgValue,
+			Background: bgValue,
+			Ratio:      math.Round(ratio*100) / 100,
+			Required:   required,
+		})
+	}
+}
+
+// hasLabel reports whether a form control is labelled by label[for], ARIA or a title
+func (a *accessibilityAudit) hasLabel(n *html.Node) bool {
+	if id := strings.TrimSpace(attrValue(n, "id")); id != "" && a.labelFor[id] {
+		return true
+	}
+	if strings.TrimSpace(attrValue(n, "aria-label")) != "" || a.labelledByText(n) != "" {
+		return true
+	}
+	return strings.TrimSpace(attrValue(n, "title")) != ""
+}
+
+// labelledByText returns the text of the elements referenced by aria-labelledby
+func (a *accessibilityAudit) labelledByText(n *html.Node) string {
+	var parts []string
+	for _, id := range strings.Fields(attrValue(n, "aria-labelledby")) {
+		if target := a.ids[id]; target != nil {
+			if text := textAlternative(target); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// accessibleName approximates the accessible name computation for buttons and links
+func (a *accessibilityAudit) accessibleName(n *html.Node) string {
+	if name := a.labelledByText(n); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(attrValue(n, "aria-label")); name != "" {
+		return name
+	}
+	if n.Data == "input" {
+		switch strings.ToLower(attrValue(n, "type")) {
+		case "image":
+			if alt := strings.TrimSpace(attrValue(n, "alt")); alt != "" {
+				return alt
+			}
+		case "submit", "reset":
+			// Browsers supply a default label when value is absent
+			if _, ok := attrLookup(n, "value"); !ok {
+				return strings.ToLower(attrValue(n, "type"))
+			}
+			fallthrough
+		default:
+			if value := strings.TrimSpace(attrValue(n, "value")); value != "" {
+				return value
+			}
+		}
+	}
+	if text := textAlternative(n); text != "" {
+		return text
+	}
+	return strings.TrimSpace(attrValue(n, "title"))
+}
+
+// checkFormLabels reports form controls without a programmatic label
+func checkFormLabels(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{"wcag": []string{wcagInfoAndRelationships, wcagNameRoleValue}, "unlabeled": audit.unlabeled}
+
+	if audit.controls == 0 {
+		return models.CheckResult{
+			Name:      "Form Labels",
+			Status:    models.StatusPass,
+			Message:   "No form controls found",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(audit.unlabeled) == 0 {
+		return models.CheckResult{
+			Name:      "Form Labels",
+			Status:    models.StatusPass,
+			Message:   "All form controls are labelled",
+			Details:   fmt.Sprintf("%d controls checked (WCAG %s)", audit.controls, wcagInfoAndRelationships),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Form Labels",
+		Status:    models.StatusFail,
+		Message:   fmt.Sprintf("%d of %d form controls have no label", len(audit.unlabeled), audit.controls),
+		Details:   fmt.Sprintf("WCAG %s, %s. Associate a <label for> or aria-label; placeholders are not labels: %s", wcagInfoAndRelationships, wcagNameRoleValue, summarizeElements(audit.unlabeled)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkAccessibleNames reports buttons and links that screen readers cannot announce
+func checkAccessibleNames(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{
+		"wcag":            []string{wcagNameRoleValue, wcagLinkPurpose},
+		"unnamed_buttons": audit.unnamed,
+		"unnamed_links":   audit.vagueLinks,
+	}
+
+	if len(audit.unnamed) == 0 && len(audit.vagueLinks) == 0 {
+		return models.CheckResult{
+			Name:      "Button and Link Names",
+			Status:    models.StatusPass,
+			Message:   "All buttons and links have accessible names",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var problems []string
+	if len(audit.unnamed) > 0 {
+		problems = append(problems, fmt.Sprintf("Buttons without names (WCAG %s): %s", wcagNameRoleValue, summarizeElements(audit.unnamed)))
+	}
+	if len(audit.vagueLinks) > 0 {
+		problems = append(problems, fmt.Sprintf("Links without names (WCAG %s): %s", wcagLinkPurpose, summarizeElements(audit.vagueLinks)))
+	}
+
+	return models.CheckResult{
+		Name:      "Button and Link Names",
+		Status:    models.StatusFail,
+		Message:   fmt.Sprintf("%d buttons and %d links have no accessible name", len(audit.unnamed), len(audit.vagueLinks)),
+		Details:   strings.Join(problems, ". ") + ". Add visible text, alt text on icon images, or aria-label",
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkARIAUsage reports unknown roles, unknown or invalid aria-* attributes and dangling ID references
+func checkARIAUsage(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{
+		"wcag":          []string{wcagNameRoleValue},
+		"invalid_roles": audit.badRoles,
+		"invalid_attrs": audit.badAttrs,
+		"broken_refs":   audit.brokenRefs,
+	}
+
+	if len(audit.badRoles) == 0 && len(audit.badAttrs) == 0 && len(audit.brokenRefs) == 0 {
+		return models.CheckResult{
+			Name:      "ARIA Roles and Attributes",
+			Status:    models.StatusPass,
+			Message:   "ARIA roles and attributes are valid",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var problems []string
+	if len(audit.badRoles) > 0 {
+		problems = append(problems, "Invalid roles: "+summarizeElements(audit.badRoles))
+	}
+	if len(audit.badAttrs) > 0 {
+		problems = append(problems, "Invalid attributes: "+summarizeElements(audit.badAttrs))
+	}
+	if len(audit.brokenRefs) > 0 {
+		problems = append(problems, "Broken references: "+summarizeElements(audit.brokenRefs))
+	}
+
+	return models.CheckResult{
+		Name:      "ARIA Roles and Attributes",
+		Status:    models.StatusFail,
+		Message:   "Invalid ARIA usage found",
+		Details:   fmt.Sprintf("WCAG %s. %s", wcagNameRoleValue, strings.Join(problems, ". ")),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkLandmarks verifies the page has exactly one main landmark and reports the others present
+func checkLandmarks(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{"wcag": []string{wcagInfoAndRelationships, wcagBypassBlocks}, "landmarks": audit.landmarks}
+
+	var present []string
+	for landmark, count := range audit.landmarks {
+		present = append(present, fmt.Sprintf("%s×%d", landmark, count))
+	}
+	sort.Strings(present)
+	details := "Landmarks: none"
+	if len(present) > 0 {
+		details = "Landmarks: " + strings.Join(present, ", ")
+	}
+
+	switch mains := audit.landmarks["main"]; {
+	case mains == 0:
+		return models.CheckResult{
+			Name:      "Landmarks",
+			Status:    models.StatusWarning,
+			Message:   "No main landmark",
+			Outcome:   "main-landmark-missing",
+			Details:   fmt.Sprintf("WCAG %s, %s. Wrap the primary content in <main> so assistive technology can skip to it. %s", wcagInfoAndRelationships, wcagBypassBlocks, details),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	case mains > 1:
+		return models.CheckResult{
+			Name:      "Landmarks",
+			Status:    models.StatusWarning,
+			Message:   fmt.Sprintf("%d main landmarks", mains),
+			Outcome:   "multiple-main-landmarks",
+			Details:   fmt.Sprintf("WCAG %s. A page should have one visible main landmark. %s", wcagInfoAndRelationships, details),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if audit.landmarks["navigation"] == 0 {
+		return models.CheckResult{
+			Name:      "Landmarks",
+			Status:    models.StatusPass,
+			Message:   "Main landmark present",
+			Details:   details + ". Consider marking menus with <nav>",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Landmarks",
+		Status:    models.StatusPass,
+		Message:   "Page regions are marked with landmarks",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkDuplicateIDs reports repeated id values; duplicates used by labels or ARIA break the association
+func checkDuplicateIDs(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	var duplicates, referenced []string
+	for id, count := range audit.idCounts {
+		if count < 2 {
+			continue
+		}
+		duplicates = append(duplicates, fmt.Sprintf("#%s×%d", id, count))
+		if audit.referenced[id] {
+			referenced = append(referenced, "#"+id)
+		}
+	}
+	sort.Strings(duplicates)
+	sort.Strings(referenced)
+
+	evidence := map[string]any{"wcag": []string{wcagParsing, wcagNameRoleValue}, "duplicates": duplicates, "referenced": referenced}
+
+	if len(duplicates) == 0 {
+		return models.CheckResult{
+			Name:      "Duplicate IDs",
+			Status:    models.StatusPass,
+			Message:   "All element IDs are unique",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(referenced) > 0 {
+		return models.CheckResult{
+			Name:      "Duplicate IDs",
+			Status:    models.StatusFail,
+			Message:   "Duplicate IDs break label or ARIA references",
+			Details:   fmt.Sprintf("WCAG %s, %s. Referenced duplicates: %s. All duplicates: %s", wcagParsing, wcagNameRoleValue, strings.Join(referenced, ", "), summarizeElements(duplicates)),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Duplicate IDs",
+		Status:    models.StatusWarning,
+		Message:   fmt.Sprintf("%d duplicate IDs", len(duplicates)),
+		Details:   fmt.Sprintf("WCAG %s. %s", wcagParsing, summarizeElements(duplicates)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkTabindex reports positive tabindex values, which override the natural focus order
+func checkTabindex(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{"wcag": []string{wcagFocusOrder}, "positive_tabindex": audit.positiveTab}
+
+	if len(audit.positiveTab) == 0 {
+		return models.CheckResult{
+			Name:      "Tabindex",
+			Status:    models.StatusPass,
+			Message:   "No positive tabindex values",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Tabindex",
+		Status:    models.StatusWarning,
+		Message:   fmt.Sprintf("%d elements use a positive tabindex", len(audit.positiveTab)),
+		Details:   fmt.Sprintf("WCAG %s. Use tabindex=\"0\" and DOM order instead: %s", wcagFocusOrder, summarizeElements(audit.positiveTab)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkFrameTitles reports iframes without a title describing their content
+func checkFrameTitles(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{"wcag": []string{wcagNameRoleValue}, "untitled": audit.untitled}
+
+	if len(audit.untitled) == 0 {
+		message := "All iframes have titles"
+		if audit.frames == 0 {
+			message = "No iframes found"
+		}
+		return models.CheckResult{
+			Name:      "Frame Titles",
+			Status:    models.StatusPass,
+			Message:   message,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Frame Titles",
+		Status:    models.StatusFail,
+		Message:   fmt.Sprintf("%d of %d iframes have no title", len(audit.untitled), audit.frames),
+		Details:   fmt.Sprintf("WCAG %s. %s", wcagNameRoleValue, summarizeElements(audit.untitled)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkColorContrast reports inline text colors below the AA contrast ratio
+func checkColorContrast(audit *accessibilityAudit, timestamp time.Time) models.CheckResult {
+	evidence := map[string]any{"wcag": []string{wcagContrastMinimum}, "checked": audit.colorPairs, "issues": audit.contrast}
+
+	if audit.colorPairs == 0 {
+		return models.CheckResult{
+			Name:      "Color Contrast",
+			Status:    models.StatusPass,
+			Message:   "No inline color pairs to check",
+			Details:   "Contrast is only evaluated for text and background colors set in style attributes",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(audit.contrast) == 0 {
+		return models.CheckResult{
+			Name:      "Color Contrast",
+			Status:    models.StatusPass,
+			Message:   "Inline colors meet the AA contrast ratio",
+			Details:   fmt.Sprintf("%d inline color pairs checked (WCAG %s)", audit.colorPairs, wcagContrastMinimum),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var examples []string
+	for _, issue := range audit.contrast {
+		examples = append(examples, fmt.Sprintf("%s %s on %s is %.2f:1 (needs %.1f:1)", issue.Element, issue.Foreground, issue.Background, issue.Ratio, issue.Required))
+	}
+
+	return models.CheckResult{
+		Name:      "Color Contrast",
+		Status:    models.StatusFail,
+		Message:   fmt.Sprintf("%d of %d inline color pairs have insufficient contrast", len(audit.contrast), audit.colorPairs),
+		Details:   fmt.Sprintf("WCAG %s. %s", wcagContrastMinimum, summarizeElements(examples)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// walkElements calls fn for every element in document order; returning false skips the element's children
+func walkElements(n *html.Node, fn func(*html.Node) bool) {
+	if n.Type == html.ElementNode && !fn(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkElements(c, fn)
+	}
+}
+
+// attrLookup returns the value of an attribute and whether it is present
+func attrLookup(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if strings.EqualFold(attr.Key, key) {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// attrValue returns the value of an attribute, or "" when absent
+func attrValue(n *html.Node, key string) string {
+	value, _ := attrLookup(n, key)
+	return value
+}
+
+// hasAttr reports whether n carries the attribute
+func hasAttr(n *html.Node, key string) bool {
+	_, ok := attrLookup(n, key)
+	return ok
+}
+
+// isHiddenElement reports whether n is removed from the accessibility tree
+func isHiddenElement(n *html.Node) bool {
+	if hasAttr(n, "hidden") || strings.EqualFold(strings.TrimSpace(attrValue(n, "aria-hidden")), "true") {
+		return true
+	}
+	display := parseInlineStyle(attrValue(n, "style"))["display"]
+	return display == "none"
+}
+
+// textAlternative returns the visible text of n, including image alt text and skipping hidden content
+func textAlternative(n *html.Node) string {
+	var parts []string
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			if text := strings.TrimSpace(n.Data); text != "" {
+				parts = append(parts, text)
+			}
+			return
+		case html.ElementNode:
+			if isHiddenElement(n) {
+				return
+			}
+			switch n.Data {
+			case "img", "area":
+				if alt := strings.TrimSpace(attrValue(n, "alt")); alt != "" {
+					parts = append(parts, alt)
+				}
+				return
+			case "script", "style", "template":
+				return
+			}
+			if label := strings.TrimSpace(attrValue(n, "aria-label")); label != "" {
+				parts = append(parts, label)
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return strings.Join(parts, " ")
+}
+
+// hasSectioningAncestor reports whether n is inside article, aside, main, nav or section
+func hasSectioningAncestor(n *html.Node) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode {
+			continue
+		}
+		switch p.Data {
+		case "article", "aside", "main", "nav", "section":
+			return true
+		}
+	}
+	return false
+}
+
+// hasTextContent reports whether n has visible, non-whitespace text
+func hasTextContent(n *html.Node) bool {
+	found := false
+	var search func(*html.Node)
+	search = func(n *html.Node) {
+		for c := n.FirstChild; c != nil && !found; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode && strings.TrimSpace(c.Data) != "":
+				found = true
+			case c.Type == html.ElementNode && c.Data != "script" && c.Data != "style" && !isHiddenElement(c):
+				search(c)
+			}
+		}
+	}
+	search(n)
+	return found
+}
+
+// describeElement renders a short opening tag identifying n in results
+func describeElement(n *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + n.Data)
+	for _, key := range []string{"id", "name", "type", "role", "class", "href", "src"} {
+		if value, ok := attrLookup(n, key); ok {
+			if len(value) > 40 {
+				value = value[:40] + "…"
+			}
+			fmt.Fprintf(&b, " %s=%q", key, value)
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// summarizeElements joins the first few entries and counts the rest
+func summarizeElements(items []string) string {
+	if len(items) <= maxReportedElements {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:maxReportedElements], ", "), len(items)-maxReportedElements)
+}
+
+// parseInlineStyle splits a style attribute into lower-cased property/value pairs
+func parseInlineStyle(style string) map[string]string {
+	declarations := make(map[string]string)
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		declarations[strings.ToLower(strings.TrimSpace(property))] = strings.ToLower(value)
+	}
+	return declarations
+}
+
+// inlineBackground returns the background color declared in a parsed style attribute
+func inlineBackground(style map[string]string) (string, bool) {
+	if value, ok := style["background-color"]; ok {
+		return value, true
+	}
+	if value, ok := style["background"]; ok {
+		// The shorthand may list an image or position before the color; take the first color token
+		for _, token := range splitCSSValue(value) {
+			if _, ok := parseCSSColor(token); ok {
+				return token, true
+			}
+		}
+	}
+	return "", false
+}
+
+// inheritedColor finds the nearest inline declaration on n or its ancestors and parses it
+func inheritedColor(n *html.Node, declared func(map[string]string) (string, bool)) (string, [3]float64, bool) {
+	for e := n; e != nil; e = e.Parent {
+		if e.Type != html.ElementNode {
+			continue
+		}
+		value, ok := declared(parseInlineStyle(attrValue(e, "style")))
+		if !ok {
+			continue
+		}
+		rgb, ok := parseCSSColor(value)
+		return value, rgb, ok
+	}
+	return "", [3]float64{}, false
+}
+
+// splitCSSValue splits a value on whitespace outside parentheses
+func splitCSSValue(value string) []string {
+	var tokens []string
+	depth, start := 0, -1
+	for i, r := range value {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case (r == ' ' || r == '\t') && depth == 0:
+			if start >= 0 {
+				tokens = append(tokens, value[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, value[start:])
+	}
+	return tokens
+}
+
+// parseCSSColor parses hex, rgb()/rgba() and basic named colors; translucent colors are rejected
+// because their contrast depends on what is behind them
+func parseCSSColor(value string) ([3]float64, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if rgb, ok := cssNamedColors[value]; ok {
+		return rgb, true
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		switch len(hex) {
+		case 3, 4:
+			if len(hex) == 4 && hex[3] != 'f' {
+				return [3]float64{}, false
+			}
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		case 6:
+		case 8:
+			if hex[6:] != "ff" {
+				return [3]float64{}, false
+			}
+			hex = hex[:6]
+		default:
+			return [3]float64{}, false
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return [3]float64{}, false
+		}
+		return [3]float64{float64(n >> 16 & 0xff), float64(n >> 8 & 0xff), float64(n & 0xff)}, true
+	}
+
+	for _, fn := range []string{"rgba(", "rgb("} {
+		if !strings.HasPrefix(value, fn) || !strings.HasSuffix(value, ")") {
+			continue
+		}
+		args := strings.FieldsFunc(value[len(fn):len(value)-1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/'
+		})
+		if len(args) < 3 || len(args) > 4 {
+			return [3]float64{}, false
+		}
+		if len(args) == 4 && args[3] != "1" && args[3] != "100%" {
+			return [3]float64{}, false
+		}
+		var rgb [3]float64
+		for i := 0; i < 3; i++ {
+			channel, percent := strings.CutSuffix(args[i], "%")
+			v, err := strconv.ParseFloat(channel, 64)
+			if err != nil {
+				return [3]float64{}, false
+			}
+			if percent {
+				v = v * 255 / 100
+			}
+			rgb[i] = math.Max(0, math.Min(255, v))
+		}
+		return rgb, true
+	}
+
+	return [3]float64{}, false
+}
+
+// contrastRatio computes the WCAG contrast ratio between two sRGB colors
+func contrastRatio(a, b [3]float64) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance implements the WCAG 2 relative luminance formula
+func relativeLuminance(rgb [3]float64) float64 {
+	var linear [3]float64
+	for i, channel := range rgb {
+		c := channel / 255
+		if c <= 0.03928 {
+			linear[i] = c / 12.92
+		} else {
+			linear[i] = math.Pow((c+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*linear[0] + 0.7152*linear[1] + 0.0722*linear[2]
+}
+
+// isLargeText reports whether inline styles make n large text (18pt, or 14pt bold), which needs only 3:1
+func isLargeText(n *html.Node) bool {
+	var size float64
+	bold, weightSet := false, false
+	switch n.Data {
+	case "b", "strong", "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		bold = true
+	}
+	// The nearest declaration of each property wins, as with CSS inheritance
+	for e := n; e != nil; e = e.Parent {
+		if e.Type != html.ElementNode {
+			continue
+		}
+		style := parseInlineStyle(attrValue(e, "style"))
+		if size == 0 {
+			size = cssPointSize(style["font-size"])
+		}
+		if weight, ok := style["font-weight"]; ok && !weightSet {
+			weightSet = true
+			value, err := strconv.Atoi(weight)
+			bold = weight == "bold" || weight == "bolder" || (err == nil && value >= 700)
+		}
+	}
+	return size >= 18 || (bold && size >= 14)
+}
+
+// cssPointSize converts a px or pt font size to points, returning 0 for other units
+func cssPointSize(value string) float64 {
+	if number, ok := strings.CutSuffix(value, "pt"); ok {
+		if v, err := strconv.ParseFloat(number, 64); err == nil {
+			return v
+		}
+	}
+	if number, ok := strings.CutSuffix(value, "px"); ok {
+		if v, err := strconv.ParseFloat(number, 64); err == nil {
+			return v * 0.75
+		}
+	}
+	return 0
+}
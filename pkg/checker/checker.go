@@ -1,11 +1,13 @@
 package checker
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/checkly-go/checkly/pkg/models"
+	"github.com/checkly-go/checkly/pkg/report"
 )
 
 type Checker struct {
@@ -16,6 +18,23 @@ type Config struct {
 	Timeout    time.Duration
 	UserAgent  string
 	Concurrent bool
+	APIPaths   []string // Extra paths probed by the CORS check
+	Checkers   []string // Checkers to run, named as in the CLI's -checkers flag; empty runs them all
+
+	VulnerabilityDB *VulnerabilityDB        // JavaScript library advisories; defaults to the embedded database
+	TechnologyRules []TechnologyRule        // Fingerprinting rules; defaults to DefaultTechnologyRules
+	Resolver        Resolver                // DNS resolver for the email checks; defaults to the system resolver
+	DNSClient       *DNSClient              // Raw DNS client for the DNS hygiene checks; defaults to the system name server
+	TakeoverRules   []TakeoverFingerprint   // Subdomain takeover fingerprints; defaults to DefaultTakeoverFingerprints
+	PageBudgets     *PageBudgets            // Page weight budgets; defaults to DefaultPageBudgets
+	ScoringProfile  *report.ScoringProfile  // Weights for the overall score; defaults to report.DefaultScoringProfile
+	Suppressions    *report.SuppressionList // Accepted findings left out of the score; optional
+
+	// Active probes below are opt-in because they send many requests to the audited site
+	ExposureScan  bool           // Probe well-known sensitive paths
+	ExposurePaths []ExposurePath // Paths for the exposure scan; defaults to DefaultExposurePaths
+	MethodProbe   bool           // Probe OPTIONS/TRACE/PUT/DELETE, error pages and default pages
+	ProbeDelay    time.Duration  // Delay between active probe requests
 }
 
 func NewChecker() *Checker {
@@ -24,6 +43,7 @@ func NewChecker() *Checker {
 			Timeout:    30 * time.Second,
 			UserAgent:  "Website-Checker/1.0",
 			Concurrent: true,
+			ProbeDelay: 250 * time.Millisecond,
 		},
 	}
 }
@@ -38,73 +58,259 @@ func (c *Checker) CheckWebsite(url string) (*models.WebsiteReport, error) {
 		Results:   []models.CheckResult{},
 	}
 
-	// Fetch HTML content for SEO checks
-	htmlContent, err := c.fetchHTMLContent(url)
-	if err != nil {
-		// If we can't fetch HTML, we'll still run other checks
-		htmlContent = ""
+	// Fetch the main document for the HTML and response-based checks
+	page, err := c.fetchPage(url)
+	htmlContent := ""
+	if err == nil {
+		htmlContent = page.Body
+	}
+	if page != nil && c.runs("tech") {
+		report.Technologies = DetectTechnologies(c.Config.TechnologyRules, url, page.Header, htmlContent)
+	}
+
+	// Run individual checks; lap measures each one for the per-result durations
+	lap := newLapTimer()
+
+	if c.runs("robots") {
+		robotsResult := CheckRobotsTxt(url)
+		report.Results = append(report.Results, tagResults("robots", lap(), robotsResult)...)
 	}
 
-	// Run individual checks
-	robotsResult := CheckRobotsTxt(url)
-	report.Results = append(report.Results, robotsResult)
+	if c.runs("securitytxt") {
+		securityTxtResult := CheckSecurityTxt(url)
+		report.Results = append(report.Results, tagResults("securitytxt", lap(), securityTxtResult)...)
+	}
 
-	sitemapResult := CheckSitemap(url, "")
-	report.Results = append(report.Results, sitemapResult)
+	if c.runs("sitemap") {
+		sitemapResult := CheckSitemap(url, "")
+		report.Results = append(report.Results, tagResults("sitemap", lap(), sitemapResult)...)
+	}
 
-	securityResults := CheckSecurityHeaders(url)
-	report.Results = append(report.Results, securityResults...)
+	if c.runs("security") {
+		securityResults := CheckSecurityHeaders(url, c.Config.TechnologyRules)
+		report.Results = append(report.Results, tagResults("security", lap(), securityResults...)...)
+
+		preloadResult := CheckHSTSPreload(url)
+		report.Results = append(report.Results, tagResults("security", lap(), preloadResult)...)
+	}
+
+	if c.runs("cors") {
+		corsResults := CheckCORS(url, c.Config.APIPaths)
+		report.Results = append(report.Results, tagResults("security", lap(), corsResults...)...)
+	}
+
+	if c.runs("email") {
+		emailResults := CheckEmailSecurity(url, c.Config.Resolver)
+		report.Results = append(report.Results, tagResults("email", lap(), emailResults...)...)
+	}
+
+	if c.runs("dns") {
+		dnsResults := CheckDNSHygiene(url, c.Config.DNSClient, c.Config.TakeoverRules)
+		report.Results = append(report.Results, tagResults("dns", lap(), dnsResults...)...)
+	}
+
+	if c.Config.ExposureScan {
+		paths := c.Config.ExposurePaths
+		if len(paths) == 0 {
+			paths = DefaultExposurePaths()
+		}
+		exposureResults := CheckSensitiveFiles(url, paths, c.Config.ProbeDelay)
+		report.Results = append(report.Results, tagResults("security", lap(), exposureResults...)...)
+	}
+
+	if c.Config.MethodProbe {
+		methodResults := CheckHTTPMethods(url, c.Config.ProbeDelay)
+		report.Results = append(report.Results, tagResults("security", lap(), methodResults...)...)
+	}
+
+	if c.runs("performance") {
+		timing, timingResults := CheckResponseTiming(url)
+		report.Timing = timing
+		report.Results = append(report.Results, tagResults("performance", lap(), timingResults...)...)
+	}
+	if page != nil && page.StatusCode < 400 && c.runs("performance") {
+		compressionResults := CheckCompression(page)
+		report.Results = append(report.Results, tagResults("performance", lap(), compressionResults...)...)
+
+		cachingResults := CheckCaching(page)
+		report.Results = append(report.Results, tagResults("performance", lap(), cachingResults...)...)
+
+		protocolResults := CheckHTTPProtocol(page)
+		report.Results = append(report.Results, tagResults("performance", lap(), protocolResults...)...)
+
+		weightResults := CheckPageWeight(page.URL, htmlContent, c.Config.PageBudgets)
+		report.Results = append(report.Results, tagResults("performance", lap(), weightResults...)...)
+	}
 
 	// Only run SEO checks if we have HTML content
 	if htmlContent != "" {
-		seoResults := CheckSEOMetadata(htmlContent)
-		report.Results = append(report.Results, seoResults...)
+		if c.runs("seo") {
+			seoResults := CheckSEOMetadata(htmlContent)
+			report.Results = append(report.Results, tagResults("seo", lap(), seoResults...)...)
+		}
+
+		if c.runs("accessibility") {
+			accessibilityResults := CheckAccessibility(htmlContent)
+			report.Results = append(report.Results, tagResults("accessibility", lap(), accessibilityResults...)...)
+		}
+
+		if c.runs("sri") {
+			sriResults := CheckSubresourceIntegrity(page.URL, htmlContent)
+			report.Results = append(report.Results, tagResults("security", lap(), sriResults...)...)
+		}
+
+		if c.runs("jslibs") {
+			libraryResults := CheckJSLibraries(page.URL, htmlContent, c.Config.VulnerabilityDB)
+			report.Results = append(report.Results, tagResults("security", lap(), libraryResults...)...)
+		}
 	}
 
 	// Calculate duration
 	report.Duration = time.Since(startTime)
 
-	// Calculate overall score (simple implementation)
-	passCount := 0
-	for _, result := range report.Results {
-		if result.Status == models.StatusPass {
-			passCount++
-		}
+	// Mark accepted findings, then calculate overall score
+	if c.Config.Suppressions != nil {
+		c.Config.Suppressions.Apply(report, time.Now())
+	}
+	c.scoringProfile().Apply(report)
+
+	return report, nil
+}
+
+// runs reports whether the named checker is selected; every checker runs when Config.Checkers is empty.
+// The opt-in probes are enabled by ExposureScan and MethodProbe instead.
+func (c *Checker) runs(name string) bool {
+	return len(c.Config.Checkers) == 0 || containsString(c.Config.Checkers, name)
+}
+
+// scoringProfile returns the configured scoring profile or the default one
+func (c *Checker) scoringProfile() *report.ScoringProfile {
+	if c.Config.ScoringProfile != nil {
+		return c.Config.ScoringProfile
 	}
+	return report.DefaultScoringProfile()
+}
 
-	if len(report.Results) > 0 {
-		report.OverallScore = (passCount * 100) / len(report.Results)
+// tagResults sets the report category and catalog metadata on results and splits the check's run
+// time across them
+func tagResults(category string, elapsed time.Duration, results ...models.CheckResult) []models.CheckResult {
+	for i := range results {
+		results[i].Category = category
 	}
+	report.DescribeResults(results)
+	SplitDuration(results, elapsed)
+	return results
+}
 
-	return report, nil
+// SplitDuration records elapsed as the duration of the check that produced results; checks returning
+// several results share their run time evenly, so durations add up to the time actually spent
+func SplitDuration(results []models.CheckResult, elapsed time.Duration) {
+	if len(results) == 0 {
+		return
+	}
+	share := elapsed / time.Duration(len(results))
+	for i := range results {
+		results[i].Duration = share
+	}
 }
 
-func (c *Checker) fetchHTMLContent(url string) (string, error) {
+// newLapTimer returns a function reporting the time since it was created or last called
+func newLapTimer() func() time.Duration {
+	last := time.Now()
+	return func() time.Duration {
+		now := time.Now()
+		elapsed := now.Sub(last)
+		last = now
+		return elapsed
+	}
+}
+
+// Page is the main document response, shared by the checks that build on it
+type Page struct {
+	URL        string // Final URL after redirects
+	StatusCode int
+	Proto      string
+	ProtoMajor int
+	Header     http.Header
+	Body       string // Empty for error responses
+	KeepAlive  bool   // The server didn't ask to close the connection
+}
+
+// pageClient fetches the main document outside a Checker, with the same timeout as NewChecker
+var pageClient = &http.Client{Timeout: 30 * time.Second}
+
+// FetchPage retrieves the main document with a 30 second timeout
+func FetchPage(pageURL string) (*Page, error) {
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doFetchPage(pageClient, req)
+}
+
+// fetchPage retrieves the main document using the checker's timeout and user agent
+func (c *Checker) fetchPage(url string) (*Page, error) {
 	client := &http.Client{
 		Timeout: c.Config.Timeout,
 	}
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("User-Agent", c.Config.UserAgent)
 
+	return doFetchPage(client, req)
+}
+
+// doFetchPage sends req and captures the response, dropping the body of error responses
+func doFetchPage(client *http.Client, req *http.Request) (*Page, error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	page := &Page{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
+		ProtoMajor: resp.ProtoMajor,
+		Header:     resp.Header,
+		KeepAlive:  !resp.Close,
+	}
+
 	if resp.StatusCode >= 400 {
-		return "", nil
+		return page, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return page, err
+	}
+	page.Body = string(body)
+
+	return page, nil
+}
+
+// fetchHTML retrieves the page body for the package-level checks that work on HTML, along with the
+// final URL after redirects, which relative links and origin comparisons must use
+func fetchHTML(pageURL string) (string, string, error) {
+	resp, err := http.Get(pageURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", "", fmt.Errorf("HTTP %d response from %s", resp.StatusCode, pageURL)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return string(body), nil
+	return string(body), resp.Request.URL.String(), nil
 }
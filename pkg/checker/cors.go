@@ -0,0 +1,233 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// corsProbeDomain is the attacker-controlled domain used in crafted Origin headers
+const corsProbeDomain = "checkly-cors-probe.example"
+
+// corsClient is used for CORS probes; it follows redirects so the final response is evaluated
+var corsClient = &http.Client{Timeout: 15 * time.Second}
+
+// corsProbe is a single crafted Origin sent to the target
+type corsProbe struct {
+	Label  string
+	Origin string
+}
+
+// CORSFinding records how the target answered a crafted Origin
+type CORSFinding struct {
+	Probe            string `json:"probe"`
+	Origin           string `json:"origin"`
+	AllowOrigin      string `json:"allow_origin,omitempty"`
+	AllowCredentials bool   `json:"allow_credentials"`
+	Issue            string `json:"issue,omitempty"`
+}
+
+// CheckCORS sends requests with crafted Origin headers to the audited URL and to each API path,
+// flagging reflected origins, trusted null origins, wildcards with credentials and overly broad preflights
+func CheckCORS(targetURL string, apiPaths []string) []models.CheckResult {
+	start := time.Now()
+
+	base, err := url.Parse(targetURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "CORS Policy",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	results := []models.CheckResult{checkCORSTarget("CORS Policy", base, start)}
+
+	for _, path := range apiPaths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		target, ok := resolveReference(base, path)
+		if !ok {
+			results = append(results, models.CheckResult{
+				Name:      fmt.Sprintf("CORS Policy (%s)", path),
+				Status:    models.StatusWarning,
+				Message:   "Invalid API path",
+				Outcome:   "invalid-path",
+				Details:   fmt.Sprintf("Could not resolve %q against %s", path, targetURL),
+				Timestamp: start,
+			})
+			continue
+		}
+		results = append(results, checkCORSTarget(fmt.Sprintf("CORS Policy (%s)", target.Path), target, start))
+	}
+
+	return results
+}
+
+// checkCORSTarget runs every Origin probe plus a preflight against a single URL
+func checkCORSTarget(name string, target *url.URL, timestamp time.Time) models.CheckResult {
+	host := target.Hostname()
+	probes := []corsProbe{
+		{Label: "arbitrary origin", Origin: "https://" + corsProbeDomain},
+		{Label: "null origin", Origin: "null"},
+		// Origins that slip through a starts-with or an ends-with comparison against the host
+		{Label: "prefix match", Origin: fmt.Sprintf("https://%s.%s", host, corsProbeDomain)},
+		{Label: "suffix match", Origin: fmt.Sprintf("https://%s%s", strings.ReplaceAll(corsProbeDomain, ".", "-"), host)},
+	}
+
+	var findings []CORSFinding
+	var critical, issues []string
+	wildcard := false
+
+	for _, probe := range probes {
+		resp, err := sendCORSRequest(http.MethodGet, target.String(), probe.Origin, nil)
+		if err != nil {
+			// An unreachable target says nothing about its CORS policy, so it isn't a failure
+			return models.CheckResult{
+				Name:      name,
+				Status:    models.StatusWarning,
+				Message:   "Could not probe CORS policy",
+				Outcome:   "unreachable",
+				Details:   fmt.Sprintf("The %s probe could not be sent: %v", probe.Label, err),
+				Timestamp: timestamp,
+			}
+		}
+
+		finding := CORSFinding{
+			Probe:            probe.Label,
+			Origin:           probe.Origin,
+			AllowOrigin:      resp.Header.Get("Access-Control-Allow-Origin"),
+			AllowCredentials: strings.EqualFold(resp.Header.Get("Access-Control-Allow-Credentials"), "true"),
+		}
+
+		switch {
+		case finding.AllowOrigin == "*":
+			wildcard = true
+			if finding.AllowCredentials {
+				finding.Issue = "wildcard origin with credentials"
+				critical = append(critical, "Access-Control-Allow-Origin: * combined with Allow-Credentials: true")
+			}
+		case finding.AllowOrigin == probe.Origin && finding.AllowCredentials:
+			finding.Issue = "origin reflected with credentials"
+			critical = append(critical, fmt.Sprintf("%s (%s) reflected with credentials", probe.Label, probe.Origin))
+		case finding.AllowOrigin == probe.Origin:
+			finding.Issue = "origin reflected"
+			issues = append(issues, fmt.Sprintf("%s (%s) reflected", probe.Label, probe.Origin))
+		}
+		findings = append(findings, finding)
+	}
+
+	// The preflight only matters when a foreign origin is actually allowed
+	var allowedMethods, allowedHeaders string
+	originAllowed := wildcard || len(critical) > 0 || len(issues) > 0
+	if originAllowed {
+		preflight := http.Header{}
+		preflight.Set("Access-Control-Request-Method", "DELETE")
+		preflight.Set("Access-Control-Request-Headers", "x-checkly-probe, authorization")
+		if resp, err := sendCORSRequest(http.MethodOptions, target.String(), probes[0].Origin, preflight); err == nil {
+			allowedMethods = resp.Header.Get("Access-Control-Allow-Methods")
+			allowedHeaders = resp.Header.Get("Access-Control-Allow-Headers")
+		}
+
+		if broad := broadCORSMethods(allowedMethods); len(broad) > 0 {
+			issues = append(issues, fmt.Sprintf("preflight allows %s", strings.Join(broad, ", ")))
+		}
+		headersLower := strings.ToLower(allowedHeaders)
+		if strings.TrimSpace(headersLower) == "*" || strings.Contains(headersLower, "x-checkly-probe") {
+			issues = append(issues, "preflight allows arbitrary request headers")
+		}
+	}
+
+	evidence := map[string]any{
+		"url":             target.String(),
+		"findings":        findings,
+		"allowed_methods": allowedMethods,
+		"allowed_headers": allowedHeaders,
+	}
+
+	if len(critical) > 0 {
+		return models.CheckResult{
+			Name:      name,
+			Status:    models.StatusFail,
+			Message:   "CORS policy exposes credentialed responses to other origins",
+			Details:   "Issues: " + strings.Join(append(critical, issues...), "; ") + ". Validate Origin against an explicit allowlist",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(issues) > 0 {
+		return models.CheckResult{
+			Name:      name,
+			Status:    models.StatusWarning,
+			Message:   "CORS policy is overly permissive",
+			Details:   "Issues: " + strings.Join(issues, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if wildcard {
+		return models.CheckResult{
+			Name:      name,
+			Status:    models.StatusPass,
+			Message:   "Public CORS policy without credentials",
+			Details:   "Access-Control-Allow-Origin: * is safe for public, unauthenticated resources",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      name,
+		Status:    models.StatusPass,
+		Message:   "Untrusted origins are not allowed",
+		Details:   fmt.Sprintf("%d crafted origins were rejected", len(probes)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// sendCORSRequest issues a request carrying the given Origin and extra headers
+func sendCORSRequest(method string, target string, origin string, extra http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Origin", origin)
+	for key, values := range extra {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := corsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// broadCORSMethods returns the state-changing or wildcard methods in an Access-Control-Allow-Methods value
+func broadCORSMethods(methods string) []string {
+	var broad []string
+	for _, method := range strings.Split(methods, ",") {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		switch method {
+		case "*", "PUT", "DELETE", "PATCH", "TRACE", "CONNECT":
+			broad = append(broad, method)
+		}
+	}
+	return broad
+}
@@ -0,0 +1,439 @@
+package checker
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// minCompressibleSize is the document size below which compression makes no measurable difference
+const minCompressibleSize = 1024
+
+// maxCachedAssets caps how many static assets are inspected for caching headers
+const maxCachedAssets = 10
+
+// minAssetMaxAge is the freshness lifetime static assets should have (one week)
+const minAssetMaxAge = 7 * 24 * 60 * 60
+
+// compressionEncodings are negotiated one at a time, best first
+var compressionEncodings = []string{"br", "zstd", "gzip"}
+
+// rawClient never decompresses, so the transferred size of encoded responses can be measured
+var rawClient = &http.Client{
+	Timeout:   15 * time.Second,
+	Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, DisableCompression: true},
+}
+
+// assetClient reads the caching headers of static assets
+var assetClient = &http.Client{Timeout: 10 * time.Second}
+
+// EncodingSupport records how the server answered one Accept-Encoding offer
+type EncodingSupport struct {
+	Encoding  string  `json:"encoding"`
+	Supported bool    `json:"supported"`
+	Bytes     int     `json:"bytes,omitempty"`
+	Ratio     float64 `json:"ratio,omitempty"` // Transferred size over uncompressed size
+}
+
+// AssetCaching describes the caching headers of one static asset
+type AssetCaching struct {
+	URL          string `json:"url"`
+	CacheControl string `json:"cache_control,omitempty"`
+	MaxAge       int    `json:"max_age"`
+	Immutable    bool   `json:"immutable"`
+	ETag         bool   `json:"etag"`
+	LastModified bool   `json:"last_modified"`
+	Issue        string `json:"issue,omitempty"`
+}
+
+// CheckCompression negotiates each supported content encoding for the document and reports the savings
+func CheckCompression(page *Page) []models.CheckResult {
+	start := time.Now()
+
+	original := len(page.Body)
+	if original < minCompressibleSize {
+		return []models.CheckResult{{
+			Name:      "HTML Compression",
+			Status:    models.StatusPass,
+			Message:   "Document too small to benefit from compression",
+			Details:   fmt.Sprintf("%d bytes", original),
+			Timestamp: start,
+		}}
+	}
+
+	var support []EncodingSupport
+	var supported []string
+	best := EncodingSupport{}
+	for _, encoding := range compressionEncodings {
+		result := negotiateEncoding(page.URL, encoding, original)
+		support = append(support, result)
+		if result.Supported {
+			supported = append(supported, encoding)
+			if best.Encoding == "" || result.Ratio < best.Ratio {
+				best = result
+			}
+		}
+	}
+
+	evidence := map[string]any{"uncompressed_bytes": original, "encodings": support}
+
+	if len(supported) == 0 {
+		return []models.CheckResult{{
+			Name:      "HTML Compression",
+			Status:    models.StatusFail,
+			Message:   "Document is served uncompressed",
+			Details:   fmt.Sprintf("%d bytes sent as-is for br, zstd and gzip requests. Enable Brotli or gzip at the web server or CDN", original),
+			Evidence:  evidence,
+			Timestamp: start,
+		}}
+	}
+
+	details := fmt.Sprintf("Supports %s; %s shrinks %d bytes to %d (%.0f%% of original)",
+		strings.Join(supported, ", "), best.Encoding, original, best.Bytes, best.Ratio*100)
+
+	if !containsString(supported, "br") && !containsString(supported, "zstd") {
+		return []models.CheckResult{{
+			Name:      "HTML Compression",
+			Status:    models.StatusWarning,
+			Message:   "Only gzip compression is available",
+			Details:   details + ". Brotli typically saves another 15-25% over gzip",
+			Evidence:  evidence,
+			Timestamp: start,
+		}}
+	}
+
+	return []models.CheckResult{{
+		Name:      "HTML Compression",
+		Status:    models.StatusPass,
+		Message:   "Document is compressed",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: start,
+	}}
+}
+
+// negotiateEncoding requests the document offering only encoding and measures what comes back
+func negotiateEncoding(pageURL string, encoding string, original int) EncodingSupport {
+	result := EncodingSupport{Encoding: encoding}
+
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return result
+	}
+	req.Header.Set("Accept-Encoding", encoding)
+
+	resp, err := rawClient.Do(req)
+	if err != nil {
+		return result
+	}
+	defer resp.Body.Close()
+
+	if !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), encoding) {
+		return result
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result
+	}
+
+	result.Supported = true
+	result.Bytes = len(body)
+	result.Ratio = float64(len(body)) / float64(original)
+	return result
+}
+
+// CheckCaching reviews the cache headers of the document and of its same-site static assets
+func CheckCaching(page *Page) []models.CheckResult {
+	start := time.Now()
+	return []models.CheckResult{
+		checkDocumentCaching(page.Header, start),
+		checkAssetCaching(page, start),
+	}
+}
+
+// checkDocumentCaching checks the HTML document can at least be revalidated cheaply
+func checkDocumentCaching(headers http.Header, timestamp time.Time) models.CheckResult {
+	cacheControl := headers.Get("Cache-Control")
+	etag := headers.Get("ETag")
+	lastModified := headers.Get("Last-Modified")
+
+	evidence := map[string]any{"cache_control": cacheControl, "etag": etag, "last_modified": lastModified}
+
+	var warnings []string
+	if cacheControl == "" {
+		warnings = append(warnings, "no Cache-Control header, so browsers and CDNs fall back to heuristics")
+	} else if containsString(cacheDirectives(cacheControl), "no-store") {
+		warnings = append(warnings, "no-store prevents revalidation and the back/forward cache")
+	}
+	if etag == "" && lastModified == "" {
+		warnings = append(warnings, "no ETag or Last-Modified, so every visit downloads the full document")
+	}
+
+	details := fmt.Sprintf("Cache-Control: %q, ETag: %t, Last-Modified: %t", cacheControl, etag != "", lastModified != "")
+
+	if len(warnings) > 0 {
+		return models.CheckResult{
+			Name:      "Document Caching",
+			Status:    models.StatusWarning,
+			Message:   "Document caching could be improved",
+			Details:   details + ". Issues: " + strings.Join(warnings, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Document Caching",
+		Status:    models.StatusPass,
+		Message:   "Document sends cache and validation headers",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkAssetCaching checks that scripts, styles, fonts and images on the site's own domain are cacheable for a week or more
+func checkAssetCaching(page *Page, timestamp time.Time) models.CheckResult {
+	base, err := url.Parse(page.URL)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "Static Asset Caching",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	doc, err := html.Parse(strings.NewReader(page.Body))
+	if err != nil {
+		return models.CheckResult{
+			Name:      "Static Asset Caching",
+			Status:    models.StatusFail,
+			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	// Third-party assets are outside the site's control, so only the site's own are inspected
+	site := registrableDomain(base.Hostname())
+	var candidates []string
+	for _, res := range extractSubresources(doc, base) {
+		switch res.Kind {
+		case "script", "stylesheet", "font", "image":
+		default:
+			continue
+		}
+		u, err := url.Parse(res.URL)
+		if err != nil || registrableDomain(u.Hostname()) != site || containsString(candidates, res.URL) {
+			continue
+		}
+		candidates = append(candidates, res.URL)
+	}
+	if len(candidates) > maxCachedAssets {
+		candidates = candidates[:maxCachedAssets]
+	}
+
+	if len(candidates) == 0 {
+		return models.CheckResult{
+			Name:      "Static Asset Caching",
+			Status:    models.StatusPass,
+			Message:   "No same-site static assets to check",
+			Details:   "The page doesn't reference scripts, stylesheets, fonts or images on its own domain",
+			Timestamp: timestamp,
+		}
+	}
+
+	var assets []AssetCaching
+	var poor []string
+	for _, asset := range candidates {
+		caching, err := fetchAssetCaching(asset)
+		if err != nil {
+			continue
+		}
+		if caching.Issue != "" {
+			poor = append(poor, fmt.Sprintf("%s (%s)", asset, caching.Issue))
+		}
+		assets = append(assets, caching)
+	}
+
+	evidence := map[string]any{"assets": assets}
+	details := fmt.Sprintf("%d of %d assets are cached for at least a week", len(assets)-len(poor), len(assets))
+
+	if len(poor) == 0 {
+		return models.CheckResult{
+			Name:      "Static Asset Caching",
+			Status:    models.StatusPass,
+			Message:   "Static assets are cacheable",
+			Details:   details,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	status := models.StatusWarning
+	if len(poor)*2 >= len(assets) {
+		status = models.StatusFail
+	}
+
+	return models.CheckResult{
+		Name:      "Static Asset Caching",
+		Status:    status,
+		Message:   "Static assets have short or missing cache lifetimes",
+		Details:   details + ". Serve fingerprinted assets with \"Cache-Control: public, max-age=31536000, immutable\": " + strings.Join(poor, "; "),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// fetchAssetCaching reads the caching headers of an asset with a HEAD request, falling back to GET
+func fetchAssetCaching(asset string) (AssetCaching, error) {
+	resp, err := assetClient.Head(asset)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = assetClient.Get(asset)
+	}
+	if err != nil {
+		return AssetCaching{}, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return AssetCaching{}, fmt.Errorf("HTTP %d response", resp.StatusCode)
+	}
+
+	caching := AssetCaching{
+		URL:          asset,
+		CacheControl: resp.Header.Get("Cache-Control"),
+		ETag:         resp.Header.Get("ETag") != "",
+		LastModified: resp.Header.Get("Last-Modified") != "",
+	}
+
+	directives := cacheDirectives(caching.CacheControl)
+	caching.Immutable = containsString(directives, "immutable")
+	for _, directive := range directives {
+		if value, ok := strings.CutPrefix(directive, "max-age="); ok {
+			caching.MaxAge, _ = strconv.Atoi(strings.Trim(value, `"`))
+		}
+	}
+
+	switch {
+	case caching.CacheControl == "" && resp.Header.Get("Expires") == "":
+		caching.Issue = "no Cache-Control"
+	case containsString(directives, "no-store"):
+		caching.Issue = "no-store"
+	case containsString(directives, "no-cache") && !caching.ETag && !caching.LastModified:
+		caching.Issue = "no-cache without validators"
+	case !caching.Immutable && caching.MaxAge < minAssetMaxAge && !containsString(directives, "no-cache"):
+		caching.Issue = fmt.Sprintf("max-age=%d", caching.MaxAge)
+	}
+
+	return caching, nil
+}
+
+// cacheDirectives splits a Cache-Control header into lower-cased directives
+func cacheDirectives(cacheControl string) []string {
+	var directives []string
+	for _, part := range strings.Split(cacheControl, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); part != "" {
+			directives = append(directives, part)
+		}
+	}
+	return directives
+}
+
+// CheckHTTPProtocol reports HTTP/2 negotiation, HTTP/3 advertisement via Alt-Svc, and connection reuse
+func CheckHTTPProtocol(page *Page) []models.CheckResult {
+	start := time.Now()
+	var results []models.CheckResult
+
+	if page.ProtoMajor >= 2 {
+		results = append(results, models.CheckResult{
+			Name:      "HTTP/2",
+			Status:    models.StatusPass,
+			Message:   "HTTP/2 negotiated",
+			Details:   fmt.Sprintf("The document was served over %s", page.Proto),
+			Timestamp: start,
+		})
+	} else {
+		details := fmt.Sprintf("The document was served over %s. Enable HTTP/2 to multiplex requests over one connection", page.Proto)
+		if strings.HasPrefix(page.URL, "http://") {
+			details = fmt.Sprintf("The document was served over %s without TLS; browsers only use HTTP/2 over HTTPS", page.Proto)
+		}
+		results = append(results, models.CheckResult{
+			Name:      "HTTP/2",
+			Status:    models.StatusWarning,
+			Message:   "HTTP/2 not available",
+			Details:   details,
+			Timestamp: start,
+		})
+	}
+
+	altSvc := page.Header.Get("Alt-Svc")
+	var h3 []string
+	for _, service := range splitTopLevel(altSvc, ',') {
+		protocol, _, _ := strings.Cut(strings.TrimSpace(service), "=")
+		if protocol == "h3" || strings.HasPrefix(protocol, "h3-") {
+			h3 = append(h3, protocol)
+		}
+	}
+	if len(h3) > 0 {
+		results = append(results, models.CheckResult{
+			Name:      "HTTP/3",
+			Status:    models.StatusPass,
+			Message:   "HTTP/3 advertised",
+			Details:   "Alt-Svc: " + altSvc,
+			Evidence:  map[string]any{"alt_svc": altSvc, "protocols": h3},
+			Timestamp: start,
+		})
+	} else {
+		results = append(results, models.CheckResult{
+			Name:      "HTTP/3",
+			Status:    models.StatusWarning,
+			Message:   "HTTP/3 not advertised",
+			Details:   "No h3 entry in Alt-Svc. HTTP/3 (QUIC) avoids head-of-line blocking and speeds up connections on lossy networks",
+			Evidence:  map[string]any{"alt_svc": altSvc},
+			Timestamp: start,
+		})
+	}
+
+	switch {
+	case page.ProtoMajor >= 2:
+		results = append(results, models.CheckResult{
+			Name:      "Keep-Alive",
+			Status:    models.StatusPass,
+			Message:   "Connections are reused",
+			Details:   fmt.Sprintf("%s keeps a single persistent connection", page.Proto),
+			Timestamp: start,
+		})
+	case page.KeepAlive:
+		results = append(results, models.CheckResult{
+			Name:      "Keep-Alive",
+			Status:    models.StatusPass,
+			Message:   "Connections are kept alive",
+			Details:   fmt.Sprintf("%s response without Connection: close", page.Proto),
+			Timestamp: start,
+		})
+	default:
+		results = append(results, models.CheckResult{
+			Name:      "Keep-Alive",
+			Status:    models.StatusFail,
+			Message:   "Server closes the connection after each response",
+			Details:   "Connection: close forces a new TCP and TLS handshake for every request. Enable keep-alive",
+			Timestamp: start,
+		})
+	}
+
+	return results
+}
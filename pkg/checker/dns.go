@@ -0,0 +1,216 @@
+package checker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dnsTimeout bounds each DNS query made by the DNS-based checks
+const dnsTimeout = 5 * time.Second
+
+// Resolver performs the DNS lookups used by the DNS-based checks.
+// *net.Resolver satisfies it; tests can supply a stub or point NewResolver at a local server.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// NewResolver returns a resolver that sends every query to server ("host:port"),
+// or the system resolver when server is empty
+func NewResolver(server string) Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// lookupTXT returns the TXT records for name, treating a missing name or record as no records
+func lookupTXT(resolver Resolver, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsTimeout)
+	defer cancel()
+
+	records, err := resolver.LookupTXT(ctx, name)
+	if isDNSNotFound(err) {
+		return nil, nil
+	}
+	return records, err
+}
+
+// lookupMX returns the MX records for name, treating a missing name or record as no records
+func lookupMX(resolver Resolver, name string) ([]*net.MX, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsTimeout)
+	defer cancel()
+
+	records, err := resolver.LookupMX(ctx, name)
+	if isDNSNotFound(err) {
+		return nil, nil
+	}
+	return records, err
+}
+
+// isDNSNotFound reports whether err means the name or record type doesn't exist
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// hostFromTarget accepts a URL or a bare host name and returns the lower-cased host
+func hostFromTarget(target string) string {
+	if strings.Contains(target, "://") {
+		if u, err := url.Parse(target); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+	}
+	host := strings.SplitN(target, "/", 2)[0]
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// DNSClient sends raw queries for record types the standard library can't look up (CAA, DS, DNSKEY).
+// Queries carry the DNSSEC OK bit so the AD flag of validating resolvers is visible.
+type DNSClient struct {
+	Server  string // "host:port" of a recursive resolver
+	Timeout time.Duration
+}
+
+// NewDNSClient returns a client that queries server, or the first name server
+// from /etc/resolv.conf when server is empty
+func NewDNSClient(server string) *DNSClient {
+	if server == "" {
+		server = systemNameServer()
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &DNSClient{Server: server, Timeout: dnsTimeout}
+}
+
+// Query resolves name for qtype over UDP, retrying over TCP when the answer is truncated
+func (c *DNSClient) Query(name string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	fqdn, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, err
+	}
+	query := dnsmessage.Message{
+		Header:      dnsmessage.Header{ID: id, RecursionDesired: true, AuthenticData: true},
+		Questions:   []dnsmessage.Question{{Name: fqdn, Type: qtype, Class: dnsmessage.ClassINET}},
+		Additionals: []dnsmessage.Resource{{Header: opt, Body: &dnsmessage.OPTResource{}}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.exchange("udp", packed)
+	if err == nil && resp.Header.Truncated {
+		resp, err = c.exchange("tcp", packed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("DNS query for %s failed: %w", name, err)
+	}
+	if resp.Header.ID != id {
+		return nil, fmt.Errorf("DNS query for %s failed: mismatched response ID", name)
+	}
+
+	return resp, nil
+}
+
+// exchange sends a packed query over network and unpacks the reply
+func (c *DNSClient) exchange(network string, packed []byte) (*dnsmessage.Message, error) {
+	conn, err := net.DialTimeout(network, c.Server, c.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.Timeout))
+
+	buf := make([]byte, 65535)
+	var n int
+	if network == "tcp" {
+		// DNS over TCP prefixes each message with its length
+		framed := binary.BigEndian.AppendUint16(nil, uint16(len(packed)))
+		if _, err := conn.Write(append(framed, packed...)); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		n = int(binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf[:n]); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(packed); err != nil {
+			return nil, err
+		}
+		if n, err = conn.Read(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(buf[:n]); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// answersOfType returns the answer records of the given type, skipping CNAMEs followed by the resolver
+func answersOfType(msg *dnsmessage.Message, qtype dnsmessage.Type) []dnsmessage.Resource {
+	var answers []dnsmessage.Resource
+	for _, answer := range msg.Answers {
+		if answer.Header.Type == qtype {
+			answers = append(answers, answer)
+		}
+	}
+	return answers
+}
+
+// systemNameServer returns the first name server in /etc/resolv.conf, falling back to localhost
+func systemNameServer() string {
+	data, err := os.ReadFile("/etc/resolv.conf")
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "nameserver" {
+				return net.JoinHostPort(fields[1], "53")
+			}
+		}
+	}
+	return "127.0.0.1:53"
+}
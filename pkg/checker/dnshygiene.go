@@ -0,0 +1,644 @@
+package checker
+
+import (
+	"crypto/tls"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//go:embed data/takeover_fingerprints.json
+var defaultTakeoverFingerprints []byte
+
+var (
+	takeoverFingerprintsOnce     sync.Once
+	embeddedTakeoverFingerprints []TakeoverFingerprint
+)
+
+// Record types dnsmessage has no constants for
+const (
+	dnsTypeDS     dnsmessage.Type = 43
+	dnsTypeDNSKEY dnsmessage.Type = 48
+	dnsTypeCAA    dnsmessage.Type = 257
+)
+
+// takeoverSubdomains are checked for dangling CNAMEs alongside the audited host
+var takeoverSubdomains = []string{
+	"www", "blog", "docs", "help", "support", "status", "shop", "store",
+	"cdn", "assets", "static", "app", "dev", "staging",
+}
+
+// caaIssuerDomains maps certificate issuer organisations to the CAA domains that authorise them
+var caaIssuerDomains = map[string][]string{
+	"let's encrypt":         {"letsencrypt.org"},
+	"digicert":              {"digicert.com", "symantec.com", "geotrust.com", "rapidssl.com", "thawte.com"},
+	"cloudflare":            {"digicert.com"},
+	"sectigo":               {"sectigo.com", "comodoca.com", "comodo.com", "usertrust.com", "trust-provider.com"},
+	"comodo":                {"sectigo.com", "comodoca.com", "comodo.com", "usertrust.com", "trust-provider.com"},
+	"zerossl":               {"sectigo.com", "zerossl.com"},
+	"globalsign":            {"globalsign.com"},
+	"google trust services": {"pki.goog"},
+	"amazon":                {"amazon.com", "amazontrust.com", "awstrust.com", "amazonaws.com"},
+	"godaddy":               {"godaddy.com", "starfieldtech.com"},
+	"starfield":             {"godaddy.com", "starfieldtech.com"},
+	"entrust":               {"entrust.net", "affirmtrust.com"},
+	"microsoft":             {"microsoft.com"},
+	"buypass":               {"buypass.com", "buypass.no"},
+	"ssl corporation":       {"ssl.com"},
+}
+
+// takeoverClient fetches pages on CNAMEd hosts; the error page of the target service is what matters,
+// so redirects are not followed
+var takeoverClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// TakeoverFingerprint identifies a hosting service whose unclaimed resources can be registered by anyone
+type TakeoverFingerprint struct {
+	Service     string   `json:"service"`
+	CNAME       []string `json:"cname"`                 // Patterns matched against the CNAME target
+	Fingerprint string   `json:"fingerprint,omitempty"` // Pattern matched against the page served for an unclaimed resource
+	NXDomain    bool     `json:"nxdomain,omitempty"`    // A non-existent CNAME target is claimable
+
+	cnamePatterns []*regexp.Regexp
+	fingerprint   *regexp.Regexp
+}
+
+// CAARecord is a single CAA property (RFC 8659)
+type CAARecord struct {
+	Flags uint8  `json:"flags"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// DanglingCNAME describes a CNAME whose target looks deprovisioned
+type DanglingCNAME struct {
+	Host      string `json:"host"`
+	Target    string `json:"target"`
+	Service   string `json:"service,omitempty"`
+	NXDomain  bool   `json:"nxdomain"`
+	Claimable bool   `json:"claimable"`
+}
+
+// CheckDNSHygiene reports CAA coverage of the served certificate's issuer, DNSSEC, IPv6 availability
+// and CNAMEs pointing at deprovisioned cloud services. A nil client queries the system name server,
+// and nil fingerprints use DefaultTakeoverFingerprints.
+func CheckDNSHygiene(target string, client *DNSClient, fingerprints []TakeoverFingerprint) []models.CheckResult {
+	start := time.Now()
+
+	if client == nil {
+		client = NewDNSClient("")
+	}
+	if fingerprints == nil {
+		fingerprints = DefaultTakeoverFingerprints()
+	}
+
+	host := hostFromTarget(target)
+	if host == "" || net.ParseIP(host) != nil {
+		return []models.CheckResult{{
+			Name:      "DNS Hygiene",
+			Status:    models.StatusFail,
+			Message:   "Invalid domain provided",
+			Outcome:   "invalid-domain",
+			Details:   fmt.Sprintf("Could not determine a domain name from %q", target),
+			Timestamp: start,
+		}}
+	}
+
+	port := "443"
+	if u, err := url.Parse(target); err == nil && u.Scheme == "https" && u.Port() != "" {
+		port = u.Port()
+	}
+
+	return []models.CheckResult{
+		checkCAA(client, host, port, start),
+		checkDNSSEC(client, registrableDomain(host), start),
+		checkIPv6(client, host, start),
+		checkDanglingCNAMEs(client, fingerprints, host, start),
+	}
+}
+
+// checkCAA finds the CAA record set that applies to host and checks it authorises the current certificate's issuer
+func checkCAA(client *DNSClient, host string, port string, timestamp time.Time) models.CheckResult {
+	records, foundAt, err := lookupCAA(client, host)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "CAA Records",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(records) == 0 {
+		return models.CheckResult{
+			Name:      "CAA Records",
+			Status:    models.StatusWarning,
+			Message:   "No CAA records",
+			Details:   fmt.Sprintf("Any certificate authority may issue certificates for %s. Publish CAA records naming the CAs you use", host),
+			Timestamp: timestamp,
+		}
+	}
+
+	issuer, wildcard, certErr := servedCertificateIssuer(host, port)
+	return evaluateCAA(records, foundAt, issuer, wildcard, certErr, timestamp)
+}
+
+// evaluateCAA reports whether the CAA records found at foundAt authorise the issuer of the served certificate
+func evaluateCAA(records []CAARecord, foundAt string, issuer string, wildcard bool, certErr error, timestamp time.Time) models.CheckResult {
+	tag := "issue"
+	if wildcard && hasCAATag(records, "issuewild") {
+		tag = "issuewild"
+	}
+	var allowed []string
+	for _, record := range records {
+		if record.Tag == tag {
+			domain, _, _ := strings.Cut(record.Value, ";")
+			allowed = append(allowed, strings.ToLower(strings.TrimSpace(domain)))
+		}
+	}
+
+	evidence := map[string]any{"records": records, "found_at": foundAt, "issuer": issuer}
+	details := fmt.Sprintf("CAA at %s allows: %s", foundAt, strings.Join(describeCAAValues(allowed), ", "))
+	if len(allowed) == 0 {
+		details = fmt.Sprintf("CAA at %s has no %s property, so issuance is unrestricted", foundAt, tag)
+	}
+	if !hasCAATag(records, "iodef") {
+		details += ". Add an iodef property to be notified of refused requests"
+	}
+
+	if certErr != nil {
+		return models.CheckResult{
+			Name:      "CAA Records",
+			Status:    models.StatusWarning,
+			Message:   "CAA records present; certificate issuer unknown",
+			Outcome:   "issuer-unknown",
+			Details:   fmt.Sprintf("%s. Could not read the served certificate: %v", details, certErr),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(allowed) == 0 {
+		return models.CheckResult{
+			Name:      "CAA Records",
+			Status:    models.StatusPass,
+			Message:   "CAA records present",
+			Details:   details,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	issuerDomains := caaDomainsForIssuer(issuer)
+	if len(issuerDomains) == 0 {
+		return models.CheckResult{
+			Name:      "CAA Records",
+			Status:    models.StatusWarning,
+			Message:   "CAA records present; issuer not recognised",
+			Outcome:   "issuer-unrecognised",
+			Details:   fmt.Sprintf("%s. Confirm that %q is covered by one of them", details, issuer),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	for _, domain := range issuerDomains {
+		if containsString(allowed, domain) {
+			return models.CheckResult{
+				Name:      "CAA Records",
+				Status:    models.StatusPass,
+				Message:   "CAA records authorise the current certificate authority",
+				Details:   fmt.Sprintf("%s. Certificate issued by %s", details, issuer),
+				Evidence:  evidence,
+				Timestamp: timestamp,
+			}
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "CAA Records",
+		Status:    models.StatusFail,
+		Message:   "CAA records do not authorise the current certificate authority",
+		Details:   fmt.Sprintf("%s. The certificate was issued by %s (%s), so renewals will be refused", details, issuer, strings.Join(issuerDomains, "/")),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// lookupCAA climbs from host towards the TLD and returns the first non-empty CAA record set (RFC 8659 section 3)
+func lookupCAA(client *DNSClient, host string) ([]CAARecord, string, error) {
+	labels := strings.Split(host, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		name := strings.Join(labels[i:], ".")
+		msg, err := client.Query(name, dnsTypeCAA)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var records []CAARecord
+		for _, answer := range answersOfType(msg, dnsTypeCAA) {
+			if body, ok := answer.Body.(*dnsmessage.UnknownResource); ok {
+				if record, ok := parseCAA(body.Data); ok {
+					records = append(records, record)
+				}
+			}
+		}
+		if len(records) > 0 {
+			return records, name, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// parseCAA decodes the wire format of a CAA record: flags, tag length, tag, value
+func parseCAA(data []byte) (CAARecord, bool) {
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return CAARecord{}, false
+	}
+	tagEnd := 2 + int(data[1])
+	return CAARecord{
+		Flags: data[0],
+		Tag:   strings.ToLower(string(data[2:tagEnd])),
+		Value: string(data[tagEnd:]),
+	}, true
+}
+
+// hasCAATag reports whether any record carries tag
+func hasCAATag(records []CAARecord, tag string) bool {
+	for _, record := range records {
+		if record.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// servedCertificateIssuer returns the issuer organisation of the certificate served by host,
+// and whether it is a wildcard certificate
+func servedCertificateIssuer(host string, port string) (string, bool, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	// Only the issuer is read, so an invalid chain must not stop the check
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	if err != nil {
+		return "", false, err
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", false, fmt.Errorf("no certificate presented")
+	}
+	leaf := certs[0]
+
+	issuer := leaf.Issuer.CommonName
+	if len(leaf.Issuer.Organization) > 0 {
+		issuer = leaf.Issuer.Organization[0]
+	}
+
+	wildcard := false
+	for _, name := range leaf.DNSNames {
+		if strings.HasPrefix(name, "*.") {
+			wildcard = true
+		}
+	}
+
+	return issuer, wildcard, nil
+}
+
+// caaDomainsForIssuer returns the CAA identifiers known for an issuer organisation
+func caaDomainsForIssuer(issuer string) []string {
+	lower := strings.ToLower(issuer)
+	for name, domains := range caaIssuerDomains {
+		if strings.Contains(lower, name) {
+			return domains
+		}
+	}
+	return nil
+}
+
+// checkDNSSEC reports whether the zone publishes DNSKEYs and has a DS record at its parent
+func checkDNSSEC(client *DNSClient, domain string, timestamp time.Time) models.CheckResult {
+	dnskey, err := client.Query(domain, dnsTypeDNSKEY)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "DNSSEC",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+	ds, err := client.Query(domain, dnsTypeDS)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "DNSSEC",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	keys := len(answersOfType(dnskey, dnsTypeDNSKEY))
+	delegations := len(answersOfType(ds, dnsTypeDS))
+	validated := dnskey.Header.AuthenticData
+	evidence := map[string]any{"dnskey_records": keys, "ds_records": delegations, "validated": validated}
+
+	switch {
+	case keys == 0 && delegations == 0:
+		return models.CheckResult{
+			Name:      "DNSSEC",
+			Status:    models.StatusWarning,
+			Message:   "DNSSEC not enabled",
+			Details:   fmt.Sprintf("%s is unsigned, so DNS answers for it can be spoofed. Enable DNSSEC at your DNS provider and publish the DS record at your registrar", domain),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	case delegations == 0:
+		return models.CheckResult{
+			Name:      "DNSSEC",
+			Status:    models.StatusWarning,
+			Message:   "DNSSEC chain of trust incomplete",
+			Outcome:   "incomplete-chain",
+			Details:   fmt.Sprintf("%s publishes %d DNSKEY record(s) but the parent zone has no DS record. Add the DS record at your registrar", domain, keys),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	case keys == 0:
+		return models.CheckResult{
+			Name:      "DNSSEC",
+			Status:    models.StatusFail,
+			Message:   "DNSSEC misconfigured",
+			Details:   fmt.Sprintf("The parent zone has a DS record for %s but no DNSKEY is published; validating resolvers will fail to resolve the domain", domain),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	details := fmt.Sprintf("%s has %d DNSKEY and %d DS record(s)", domain, keys, delegations)
+	if validated {
+		details += "; answers were validated by the resolver"
+	} else {
+		details += "; the resolver did not report validation"
+	}
+
+	return models.CheckResult{
+		Name:      "DNSSEC",
+		Status:    models.StatusPass,
+		Message:   "DNSSEC enabled",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkIPv6 reports whether host resolves to any IPv6 address
+func checkIPv6(client *DNSClient, host string, timestamp time.Time) models.CheckResult {
+	msg, err := client.Query(host, dnsmessage.TypeAAAA)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "IPv6 (AAAA)",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	var addresses []string
+	for _, answer := range answersOfType(msg, dnsmessage.TypeAAAA) {
+		if body, ok := answer.Body.(*dnsmessage.AAAAResource); ok {
+			addresses = append(addresses, net.IP(body.AAAA[:]).String())
+		}
+	}
+
+	if len(addresses) == 0 {
+		return models.CheckResult{
+			Name:      "IPv6 (AAAA)",
+			Status:    models.StatusWarning,
+			Message:   "No IPv6 address",
+			Details:   fmt.Sprintf("%s has no AAAA record, so IPv6-only clients can't reach it", host),
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "IPv6 (AAAA)",
+		Status:    models.StatusPass,
+		Message:   "Reachable over IPv6",
+		Details:   fmt.Sprintf("%s resolves to %s", host, strings.Join(addresses, ", ")),
+		Evidence:  map[string]any{"addresses": addresses},
+		Timestamp: timestamp,
+	}
+}
+
+// checkDanglingCNAMEs looks for CNAMEs on the host and common subdomains that point at unclaimed cloud resources
+func checkDanglingCNAMEs(client *DNSClient, fingerprints []TakeoverFingerprint, host string, timestamp time.Time) models.CheckResult {
+	domain := registrableDomain(host)
+	hosts := []string{host}
+	for _, candidate := range append([]string{domain}, prefixAll(takeoverSubdomains, domain)...) {
+		if !containsString(hosts, candidate) {
+			hosts = append(hosts, candidate)
+		}
+	}
+
+	var claimable, dangling []DanglingCNAME
+	var cnames []string
+	for _, name := range hosts {
+		msg, err := client.Query(name, dnsmessage.TypeCNAME)
+		if err != nil {
+			continue
+		}
+		for _, answer := range answersOfType(msg, dnsmessage.TypeCNAME) {
+			body, ok := answer.Body.(*dnsmessage.CNAMEResource)
+			if !ok {
+				continue
+			}
+			target := strings.ToLower(strings.TrimSuffix(body.CNAME.String(), "."))
+			cnames = append(cnames, fmt.Sprintf("%s -> %s", name, target))
+
+			finding := DanglingCNAME{Host: name, Target: target}
+			if resp, err := client.Query(target, dnsmessage.TypeA); err == nil {
+				finding.NXDomain = resp.Header.RCode == dnsmessage.RCodeNameError
+			}
+
+			service := matchTakeoverService(fingerprints, target)
+			if service != nil {
+				finding.Service = service.Service
+				finding.Claimable = (service.NXDomain && finding.NXDomain) ||
+					(service.fingerprint != nil && !finding.NXDomain && pageMatches(name, service.fingerprint))
+			}
+
+			switch {
+			case finding.Claimable:
+				claimable = append(claimable, finding)
+			case finding.NXDomain:
+				dangling = append(dangling, finding)
+			}
+		}
+	}
+
+	evidence := map[string]any{"checked": hosts, "cnames": cnames}
+	if len(dangling) > 0 {
+		evidence["dangling"] = dangling
+	}
+
+	if len(claimable) > 0 {
+		var issues []string
+		for _, f := range claimable {
+			issues = append(issues, fmt.Sprintf("%s -> %s (%s)", f.Host, f.Target, f.Service))
+		}
+		evidence["claimable"] = claimable
+		return models.CheckResult{
+			Name:      "Subdomain Takeover",
+			Status:    models.StatusFail,
+			Message:   "CNAMEs point at unclaimed cloud resources",
+			Details:   "Anyone can claim these targets and serve content on your domain: " + strings.Join(issues, "; ") + ". Remove the records or reclaim the resources",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(dangling) > 0 {
+		var issues []string
+		for _, f := range dangling {
+			issues = append(issues, fmt.Sprintf("%s -> %s", f.Host, f.Target))
+		}
+		return models.CheckResult{
+			Name:      "Subdomain Takeover",
+			Status:    models.StatusWarning,
+			Message:   "Dangling CNAME records",
+			Details:   "These CNAMEs point at names that don't exist: " + strings.Join(issues, "; ") + ". Remove them before someone registers the target",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Subdomain Takeover",
+		Status:    models.StatusPass,
+		Message:   "No dangling CNAMEs found",
+		Details:   fmt.Sprintf("Checked %d names, %d CNAME(s) resolve to live targets", len(hosts), len(cnames)),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// DefaultTakeoverFingerprints returns the takeover fingerprints embedded in the binary, parsed once
+func DefaultTakeoverFingerprints() []TakeoverFingerprint {
+	takeoverFingerprintsOnce.Do(func() {
+		fingerprints, err := parseTakeoverFingerprints(defaultTakeoverFingerprints)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded takeover fingerprints: %v", err))
+		}
+		embeddedTakeoverFingerprints = fingerprints
+	})
+	return append([]TakeoverFingerprint(nil), embeddedTakeoverFingerprints...)
+}
+
+// LoadTakeoverFingerprints reads takeover fingerprints from a JSON file, replacing the embedded ones
+func LoadTakeoverFingerprints(filename string) ([]TakeoverFingerprint, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read takeover fingerprints: %w", err)
+	}
+	return parseTakeoverFingerprints(data)
+}
+
+// parseTakeoverFingerprints decodes and compiles the takeover fingerprint list
+func parseTakeoverFingerprints(data []byte) ([]TakeoverFingerprint, error) {
+	var fingerprints []TakeoverFingerprint
+	if err := json.Unmarshal(data, &fingerprints); err != nil {
+		return nil, fmt.Errorf("failed to parse takeover fingerprints: %w", err)
+	}
+
+	for i := range fingerprints {
+		f := &fingerprints[i]
+		for _, pattern := range f.CNAME {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CNAME pattern for %s: %w", f.Service, err)
+			}
+			f.cnamePatterns = append(f.cnamePatterns, re)
+		}
+		if f.Fingerprint != "" {
+			re, err := regexp.Compile(f.Fingerprint)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fingerprint for %s: %w", f.Service, err)
+			}
+			f.fingerprint = re
+		}
+	}
+
+	return fingerprints, nil
+}
+
+// matchTakeoverService returns the service whose CNAME patterns match target
+func matchTakeoverService(fingerprints []TakeoverFingerprint, target string) *TakeoverFingerprint {
+	for i := range fingerprints {
+		for _, re := range fingerprints[i].cnamePatterns {
+			if re.MatchString(target) {
+				return &fingerprints[i]
+			}
+		}
+	}
+	return nil
+}
+
+// pageMatches fetches host over HTTPS, falling back to HTTP, and matches the body against re
+func pageMatches(host string, re *regexp.Regexp) bool {
+	for _, scheme := range []string{"https", "http"} {
+		resp, err := takeoverClient.Get(scheme + "://" + host + "/")
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxExposureBody))
+		resp.Body.Close()
+		if err == nil {
+			return re.Match(body)
+		}
+	}
+	return false
+}
+
+// prefixAll returns label.domain for every label
+func prefixAll(labels []string, domain string) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label+"."+domain)
+	}
+	return names
+}
+
+// describeCAAValues renders empty issuer domains, which forbid issuance, readably
+func describeCAAValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v == "" {
+			v = "none (\";\")"
+		}
+		out = append(out, v)
+	}
+	return out
+}
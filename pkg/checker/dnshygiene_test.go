@@ -0,0 +1,237 @@
+package checker
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startStubDNS serves CAA records over UDP on a loopback port and returns a client querying it;
+// names without records get an empty answer
+func startStubDNS(t *testing.T, caa map[string][]CAARecord) *DNSClient {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			question := query.Questions[0]
+
+			resp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.Header.ID, Response: true, RecursionAvailable: true},
+				Questions: query.Questions,
+			}
+			if question.Type == dnsTypeCAA {
+				for _, record := range caa[strings.TrimSuffix(question.Name.String(), ".")] {
+					data := append([]byte{record.Flags, byte(len(record.Tag))}, record.Tag...)
+					resp.Answers = append(resp.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsTypeCAA, Class: dnsmessage.ClassINET, TTL: 300},
+						Body:   &dnsmessage.UnknownResource{Type: dnsTypeCAA, Data: append(data, record.Value...)},
+					})
+				}
+			}
+
+			packed, err := resp.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return &DNSClient{Server: conn.LocalAddr().String(), Timeout: 2 * time.Second}
+}
+
+func TestLookupCAA(t *testing.T) {
+	client := startStubDNS(t, map[string][]CAARecord{
+		"example.com": {
+			{Tag: "issue", Value: "letsencrypt.org"},
+			{Flags: 128, Tag: "IODEF", Value: "mailto:security@example.com"},
+		},
+		"shop.example.com": {{Tag: "issue", Value: "digicert.com; cansignhttpexchanges=yes"}},
+	})
+
+	tests := []struct {
+		name    string
+		host    string
+		foundAt string
+		records []CAARecord
+	}{
+		{
+			name:    "records at the host",
+			host:    "example.com",
+			foundAt: "example.com",
+			records: []CAARecord{
+				{Tag: "issue", Value: "letsencrypt.org"},
+				{Flags: 128, Tag: "iodef", Value: "mailto:security@example.com"},
+			},
+		},
+		{
+			name:    "climbs to the parent domain",
+			host:    "www.blog.example.com",
+			foundAt: "example.com",
+			records: []CAARecord{
+				{Tag: "issue", Value: "letsencrypt.org"},
+				{Flags: 128, Tag: "iodef", Value: "mailto:security@example.com"},
+			},
+		},
+		{
+			name:    "closest record set wins",
+			host:    "shop.example.com",
+			foundAt: "shop.example.com",
+			records: []CAARecord{{Tag: "issue", Value: "digicert.com; cansignhttpexchanges=yes"}},
+		},
+		{
+			name: "no records up to the TLD",
+			host: "www.example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, foundAt, err := lookupCAA(client, tt.host)
+			if err != nil {
+				t.Fatalf("lookupCAA: %v", err)
+			}
+			if foundAt != tt.foundAt {
+				t.Errorf("foundAt = %q, want %q", foundAt, tt.foundAt)
+			}
+			if len(records) != len(tt.records) {
+				t.Fatalf("records = %+v, want %+v", records, tt.records)
+			}
+			for i := range records {
+				if records[i] != tt.records[i] {
+					t.Errorf("record %d = %+v, want %+v", i, records[i], tt.records[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCAAWithoutRecords(t *testing.T) {
+	client := startStubDNS(t, nil)
+
+	result := checkCAA(client, "www.example.org", "443", time.Now())
+	if result.Status != models.StatusWarning || result.Message != "No CAA records" {
+		t.Errorf("got %s %q, want a warning that there are no CAA records", result.Status, result.Message)
+	}
+}
+
+func TestEvaluateCAA(t *testing.T) {
+	letsEncrypt := []CAARecord{
+		{Tag: "issue", Value: "letsencrypt.org"},
+		{Tag: "iodef", Value: "mailto:security@example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		records  []CAARecord
+		issuer   string
+		wildcard bool
+		certErr  error
+		status   models.Status
+		outcome  string
+		message  string
+	}{
+		{
+			name:    "issuer authorised",
+			records: letsEncrypt,
+			issuer:  "Let's Encrypt",
+			status:  models.StatusPass,
+			message: "CAA records authorise the current certificate authority",
+		},
+		{
+			name:    "issuer matched through an alias domain",
+			records: []CAARecord{{Tag: "issue", Value: "Comodoca.com; account=42"}},
+			issuer:  "Sectigo Limited",
+			status:  models.StatusPass,
+			message: "CAA records authorise the current certificate authority",
+		},
+		{
+			name:    "issuer not authorised",
+			records: letsEncrypt,
+			issuer:  "DigiCert Inc",
+			status:  models.StatusFail,
+			message: "CAA records do not authorise the current certificate authority",
+		},
+		{
+			name:    "issuance forbidden",
+			records: []CAARecord{{Tag: "issue", Value: ";"}},
+			issuer:  "Let's Encrypt",
+			status:  models.StatusFail,
+			message: "CAA records do not authorise the current certificate authority",
+		},
+		{
+			name:     "issuewild governs wildcard certificates",
+			records:  []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}, {Tag: "issuewild", Value: "digicert.com"}},
+			issuer:   "Let's Encrypt",
+			wildcard: true,
+			status:   models.StatusFail,
+			message:  "CAA records do not authorise the current certificate authority",
+		},
+		{
+			name:     "issue governs wildcard certificates without issuewild",
+			records:  letsEncrypt,
+			issuer:   "Let's Encrypt",
+			wildcard: true,
+			status:   models.StatusPass,
+			message:  "CAA records authorise the current certificate authority",
+		},
+		{
+			name:    "no issue property",
+			records: []CAARecord{{Tag: "iodef", Value: "mailto:security@example.com"}},
+			issuer:  "DigiCert Inc",
+			status:  models.StatusPass,
+			message: "CAA records present",
+		},
+		{
+			name:    "issuer not recognised",
+			records: letsEncrypt,
+			issuer:  "Example Private CA",
+			status:  models.StatusWarning,
+			outcome: "issuer-unrecognised",
+			message: "CAA records present; issuer not recognised",
+		},
+		{
+			name:    "certificate unreadable",
+			records: letsEncrypt,
+			certErr: errors.New("connection refused"),
+			status:  models.StatusWarning,
+			outcome: "issuer-unknown",
+			message: "CAA records present; certificate issuer unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluateCAA(tt.records, "example.com", tt.issuer, tt.wildcard, tt.certErr, time.Now())
+			if result.Status != tt.status {
+				t.Errorf("status = %s, want %s (%s: %s)", result.Status, tt.status, result.Message, result.Details)
+			}
+			if result.Outcome != tt.outcome {
+				t.Errorf("outcome = %q, want %q", result.Outcome, tt.outcome)
+			}
+			if result.Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,789 @@
+package checker
+
+import (
+	"bufio"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// spfLookupLimit is the RFC 7208 cap on DNS-querying SPF terms
+const spfLookupLimit = 10
+
+// commonDKIMSelectors are probed because DKIM selectors can't be enumerated through DNS
+var commonDKIMSelectors = []string{
+	"default", "dkim", "mail", "email", "smtp", "k1", "k2", "k3", "s1", "s2",
+	"selector1", "selector2", "google", "mandrill", "mxvault", "zoho",
+	"protonmail", "protonmail2", "fm1", "fm2", "fm3", "sendgrid", "amazonses", "everlytickey1",
+}
+
+// mtaSTSClient fetches MTA-STS policies; RFC 8461 forbids following redirects
+var mtaSTSClient = &http.Client{
+	Timeout: 15 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// DKIMKey describes a DKIM public key found at a selector
+type DKIMKey struct {
+	Selector string `json:"selector"`
+	KeyType  string `json:"key_type"`
+	Bits     int    `json:"bits,omitempty"`
+	Revoked  bool   `json:"revoked"`
+}
+
+// MTASTSPolicy holds the fields of an MTA-STS policy file (RFC 8461)
+type MTASTSPolicy struct {
+	URL     string   `json:"url"`
+	Version string   `json:"version"`
+	Mode    string   `json:"mode"`
+	MX      []string `json:"mx"`
+	MaxAge  int      `json:"max_age"`
+}
+
+// CheckEmailSecurity audits the email posture of the domain behind target (a URL or a domain):
+// SPF, DMARC, DKIM at common selectors, MTA-STS and TLS-RPT. A nil resolver uses the system resolver.
+func CheckEmailSecurity(target string, resolver Resolver) []models.CheckResult {
+	start := time.Now()
+
+	if resolver == nil {
+		resolver = NewResolver("")
+	}
+
+	host := hostFromTarget(target)
+	if host == "" || net.ParseIP(host) != nil {
+		return []models.CheckResult{{
+			Name:      "Email Security",
+			Status:    models.StatusFail,
+			Message:   "Invalid domain provided",
+			Outcome:   "invalid-domain",
+			Details:   fmt.Sprintf("Could not determine a domain name from %q", target),
+			Timestamp: start,
+		}}
+	}
+	domain := registrableDomain(host)
+
+	mx, err := lookupMX(resolver, domain)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Email Security",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+	receivesMail := len(mx) > 0 && !(len(mx) == 1 && mx[0].Host == ".")
+
+	return []models.CheckResult{
+		checkSPF(resolver, domain, start),
+		checkDMARC(resolver, domain, start),
+		checkDKIM(resolver, domain, receivesMail, start),
+		checkMTASTS(resolver, domain, mx, receivesMail, start),
+		checkTLSRPT(resolver, domain, receivesMail, start),
+	}
+}
+
+// checkSPF validates the domain's SPF record and counts the DNS lookups it triggers
+func checkSPF(resolver Resolver, domain string, timestamp time.Time) models.CheckResult {
+	records, err := spfRecords(resolver, domain)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "SPF Record",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(records) == 0 {
+		return models.CheckResult{
+			Name:      "SPF Record",
+			Status:    models.StatusFail,
+			Message:   "Missing SPF record",
+			Outcome:   "missing",
+			Details:   fmt.Sprintf("Publish a TXT record at %s starting with v=spf1; use \"v=spf1 -all\" if the domain never sends mail", domain),
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(records) > 1 {
+		return models.CheckResult{
+			Name:      "SPF Record",
+			Status:    models.StatusFail,
+			Message:   "Multiple SPF records",
+			Outcome:   "multiple-records",
+			Details:   fmt.Sprintf("%s has %d v=spf1 records; receivers treat this as a permanent error. Merge them into one", domain, len(records)),
+			Evidence:  map[string]any{"records": records},
+			Timestamp: timestamp,
+		}
+	}
+
+	record := records[0]
+	var problems, warnings []string
+
+	allQualifier, hasRedirect, usesPTR, syntaxErrors := parseSPFPolicy(record)
+	problems = append(problems, syntaxErrors...)
+
+	lookups, lookupErrors := countSPFLookups(resolver, domain, record, map[string]bool{domain: true}, 0)
+	problems = append(problems, lookupErrors...)
+	if lookups > spfLookupLimit {
+		problems = append(problems, fmt.Sprintf("%d DNS lookups exceeds the limit of %d", lookups, spfLookupLimit))
+	}
+
+	switch {
+	case allQualifier == "+":
+		problems = append(problems, "\"+all\" authorises every server on the internet")
+	case allQualifier == "?":
+		warnings = append(warnings, "\"?all\" is neutral and gives receivers nothing to act on")
+	case allQualifier == "" && !hasRedirect:
+		warnings = append(warnings, "no \"all\" mechanism; end the record with ~all or -all")
+	}
+	if usesPTR {
+		warnings = append(warnings, "the ptr mechanism is deprecated and slow")
+	}
+
+	evidence := map[string]any{"record": record, "lookups": lookups}
+	details := fmt.Sprintf("%s (%d of %d DNS lookups)", record, lookups, spfLookupLimit)
+
+	if len(problems) > 0 {
+		return models.CheckResult{
+			Name:      "SPF Record",
+			Status:    models.StatusFail,
+			Message:   "SPF record is invalid or unsafe",
+			Details:   details + ". Problems: " + strings.Join(append(problems, warnings...), "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(warnings) > 0 {
+		return models.CheckResult{
+			Name:      "SPF Record",
+			Status:    models.StatusWarning,
+			Message:   "SPF record could be stricter",
+			Details:   details + ". Recommendations: " + strings.Join(warnings, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "SPF Record",
+		Status:    models.StatusPass,
+		Message:   "SPF record is valid",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// spfRecords returns the v=spf1 TXT records published at domain
+func spfRecords(resolver Resolver, domain string) ([]string, error) {
+	txt, err := lookupTXT(resolver, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+	for _, record := range txt {
+		lower := strings.ToLower(strings.TrimSpace(record))
+		if lower == "v=spf1" || strings.HasPrefix(lower, "v=spf1 ") {
+			records = append(records, strings.TrimSpace(record))
+		}
+	}
+	return records, nil
+}
+
+// parseSPFPolicy returns the qualifier of the "all" mechanism, whether a redirect is present,
+// whether the deprecated ptr mechanism is used, and any terms that aren't valid SPF syntax
+func parseSPFPolicy(record string) (string, bool, bool, []string) {
+	var allQualifier string
+	var hasRedirect, usesPTR bool
+	var invalid []string
+
+	for _, term := range strings.Fields(record)[1:] {
+		lower := strings.ToLower(term)
+
+		if name, _, ok := strings.Cut(lower, "="); ok {
+			// Unknown modifiers must be ignored (RFC 7208 section 6)
+			if name == "redirect" {
+				hasRedirect = true
+			}
+			continue
+		}
+
+		qualifier := "+"
+		if strings.ContainsAny(lower[:1], "+-~?") {
+			qualifier, lower = lower[:1], lower[1:]
+		}
+		mechanism, _, _ := strings.Cut(lower, ":")
+		mechanism, _, _ = strings.Cut(mechanism, "/")
+
+		switch mechanism {
+		case "all":
+			allQualifier = qualifier
+		case "include", "exists":
+			if !strings.Contains(lower, ":") {
+				invalid = append(invalid, fmt.Sprintf("%q needs a domain", term))
+			}
+		case "ip4", "ip6":
+			value := strings.TrimPrefix(lower, mechanism+":")
+			ip, _, _ := strings.Cut(value, "/")
+			if net.ParseIP(ip) == nil {
+				invalid = append(invalid, fmt.Sprintf("%q is not a valid address", term))
+			}
+		case "ptr":
+			usesPTR = true
+		case "a", "mx":
+		default:
+			invalid = append(invalid, fmt.Sprintf("unknown mechanism %q", term))
+		}
+	}
+
+	return allQualifier, hasRedirect, usesPTR, invalid
+}
+
+// countSPFLookups counts the DNS-querying terms of record, following include and redirect targets.
+// path holds the domains on the current include chain: a domain reached through several branches is
+// counted on each of them, as receivers evaluate it each time, while one that includes itself is a loop.
+func countSPFLookups(resolver Resolver, domain string, record string, path map[string]bool, depth int) (int, []string) {
+	if depth > spfLookupLimit {
+		return 0, []string{"include chain is too deep"}
+	}
+
+	lookups := 0
+	var problems []string
+
+	for _, term := range strings.Fields(record)[1:] {
+		lower := strings.TrimLeft(strings.ToLower(term), "+-~?")
+
+		var target string
+		switch {
+		case strings.HasPrefix(lower, "include:"):
+			target = strings.TrimPrefix(lower, "include:")
+		case strings.HasPrefix(lower, "redirect="):
+			target = strings.TrimPrefix(lower, "redirect=")
+		case lower == "a" || lower == "mx" || lower == "ptr" ||
+			strings.HasPrefix(lower, "a:") || strings.HasPrefix(lower, "a/") ||
+			strings.HasPrefix(lower, "mx:") || strings.HasPrefix(lower, "mx/") ||
+			strings.HasPrefix(lower, "ptr:") || strings.HasPrefix(lower, "exists:"):
+			lookups++
+			continue
+		default:
+			continue
+		}
+
+		lookups++
+		// Macros can't be expanded without a sender, so the target isn't followed
+		if target == "" || strings.Contains(target, "%") {
+			continue
+		}
+		if path[target] {
+			problems = append(problems, fmt.Sprintf("%s includes itself in a loop (referenced from %s)", target, domain))
+			continue
+		}
+
+		records, err := spfRecords(resolver, target)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("lookup of %s failed: %v", target, err))
+			continue
+		}
+		if len(records) != 1 {
+			problems = append(problems, fmt.Sprintf("%s (referenced from %s) has %d SPF records", target, domain, len(records)))
+			continue
+		}
+
+		path[target] = true
+		nested, nestedProblems := countSPFLookups(resolver, target, records[0], path, depth+1)
+		delete(path, target)
+		lookups += nested
+		problems = append(problems, nestedProblems...)
+	}
+
+	return lookups, problems
+}
+
+// checkDMARC validates the domain's DMARC policy and how strictly it is enforced
+func checkDMARC(resolver Resolver, domain string, timestamp time.Time) models.CheckResult {
+	name := "_dmarc." + domain
+	txt, err := lookupTXT(resolver, name)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "DMARC Policy",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	var records []string
+	for _, record := range txt {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(record)), "v=dmarc1") {
+			records = append(records, strings.TrimSpace(record))
+		}
+	}
+
+	if len(records) == 0 {
+		return models.CheckResult{
+			Name:      "DMARC Policy",
+			Status:    models.StatusFail,
+			Message:   "Missing DMARC record",
+			Outcome:   "missing",
+			Details:   fmt.Sprintf("Publish a TXT record at %s such as \"v=DMARC1; p=quarantine; rua=mailto:dmarc@%s\"", name, domain),
+			Timestamp: timestamp,
+		}
+	}
+	if len(records) > 1 {
+		return models.CheckResult{
+			Name:      "DMARC Policy",
+			Status:    models.StatusFail,
+			Message:   "Multiple DMARC records",
+			Outcome:   "multiple-records",
+			Details:   fmt.Sprintf("%s has %d DMARC records; receivers ignore DMARC entirely in this case", name, len(records)),
+			Evidence:  map[string]any{"records": records},
+			Timestamp: timestamp,
+		}
+	}
+
+	record := records[0]
+	tags := parseTagList(record)
+	evidence := map[string]any{"record": record, "tags": tags}
+
+	policy := strings.ToLower(tags["p"])
+	switch policy {
+	case "none", "quarantine", "reject":
+	default:
+		return models.CheckResult{
+			Name:      "DMARC Policy",
+			Status:    models.StatusFail,
+			Message:   "DMARC record has no valid policy",
+			Details:   fmt.Sprintf("%s: the p tag must be none, quarantine or reject", record),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var warnings []string
+	if policy == "none" {
+		warnings = append(warnings, "p=none only monitors; spoofed mail is still delivered. Move to quarantine or reject")
+	}
+	if sp := strings.ToLower(tags["sp"]); sp == "none" && policy != "none" {
+		warnings = append(warnings, "sp=none leaves subdomains unprotected")
+	}
+	if pct, ok := tags["pct"]; ok {
+		if n, err := strconv.Atoi(pct); err != nil || n < 100 {
+			warnings = append(warnings, fmt.Sprintf("pct=%s applies the policy to only part of the mail", pct))
+		}
+	}
+	if tags["rua"] == "" {
+		warnings = append(warnings, "no rua address, so no aggregate reports are received")
+	}
+
+	if len(warnings) > 0 {
+		return models.CheckResult{
+			Name:      "DMARC Policy",
+			Status:    models.StatusWarning,
+			Message:   fmt.Sprintf("DMARC policy p=%s could be stronger", policy),
+			Details:   record + ". Recommendations: " + strings.Join(warnings, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "DMARC Policy",
+		Status:    models.StatusPass,
+		Message:   fmt.Sprintf("DMARC policy p=%s is enforced", policy),
+		Details:   record,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkDKIM looks for DKIM keys at common selectors and checks their strength
+func checkDKIM(resolver Resolver, domain string, receivesMail bool, timestamp time.Time) models.CheckResult {
+	var keys []DKIMKey
+	var weak, short []string
+
+	for _, selector := range commonDKIMSelectors {
+		txt, err := lookupTXT(resolver, selector+"._domainkey."+domain)
+		if err != nil {
+			continue
+		}
+		for _, record := range txt {
+			tags := parseTagList(record)
+			p, ok := tags["p"]
+			if !ok {
+				continue
+			}
+
+			key := DKIMKey{Selector: selector, KeyType: strings.ToLower(tags["k"]), Revoked: p == ""}
+			if key.KeyType == "" {
+				key.KeyType = "rsa"
+			}
+			if !key.Revoked && key.KeyType == "rsa" {
+				key.Bits = rsaKeyBits(p)
+				switch {
+				case key.Bits > 0 && key.Bits < 1024:
+					weak = append(weak, fmt.Sprintf("%s (%d-bit)", selector, key.Bits))
+				case key.Bits > 0 && key.Bits < 2048:
+					short = append(short, fmt.Sprintf("%s (%d-bit)", selector, key.Bits))
+				}
+			}
+			keys = append(keys, key)
+			break
+		}
+	}
+
+	evidence := map[string]any{"keys": keys, "selectors_checked": commonDKIMSelectors}
+
+	if len(keys) == 0 {
+		status, outcome := models.StatusWarning, "not-found"
+		if !receivesMail {
+			status, outcome = models.StatusPass, ""
+		}
+		return models.CheckResult{
+			Name:      "DKIM Keys",
+			Status:    status,
+			Message:   "No DKIM keys found at common selectors",
+			Outcome:   outcome,
+			Details:   fmt.Sprintf("Checked %d common selectors. DKIM may still use a custom selector; confirm outgoing mail is signed", len(commonDKIMSelectors)),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var selectors []string
+	for _, key := range keys {
+		label := key.Selector
+		if key.Revoked {
+			label += " (revoked)"
+		}
+		selectors = append(selectors, label)
+	}
+	details := "Found keys at: " + strings.Join(selectors, ", ")
+
+	if len(weak) > 0 {
+		return models.CheckResult{
+			Name:      "DKIM Keys",
+			Status:    models.StatusFail,
+			Message:   "Weak DKIM keys",
+			Details:   details + ". Keys below 1024 bits can be factored: " + strings.Join(weak, ", "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+	if len(short) > 0 {
+		return models.CheckResult{
+			Name:      "DKIM Keys",
+			Status:    models.StatusWarning,
+			Message:   "DKIM keys shorter than 2048 bits",
+			Outcome:   "short-keys",
+			Details:   details + ". Rotate to 2048-bit keys: " + strings.Join(short, ", "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "DKIM Keys",
+		Status:    models.StatusPass,
+		Message:   "DKIM keys published",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// rsaKeyBits returns the modulus size of a base64 DKIM public key, or 0 if it can't be parsed
+func rsaKeyBits(encoded string) int {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return 0
+	}
+	if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+		if rsaKey, ok := pub.(*rsa.PublicKey); ok {
+			return rsaKey.N.BitLen()
+		}
+		return 0
+	}
+	if rsaKey, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return rsaKey.N.BitLen()
+	}
+	return 0
+}
+
+// checkMTASTS validates the MTA-STS DNS record and policy file, and that the policy covers every MX host
+func checkMTASTS(resolver Resolver, domain string, mx []*net.MX, receivesMail bool, timestamp time.Time) models.CheckResult {
+	if !receivesMail {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusPass,
+			Message:   "Domain does not receive mail",
+			Details:   fmt.Sprintf("%s has no MX records, so MTA-STS doesn't apply", domain),
+			Timestamp: timestamp,
+		}
+	}
+
+	name := "_mta-sts." + domain
+	txt, err := lookupTXT(resolver, name)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	var record string
+	for _, r := range txt {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(r)), "v=stsv1") {
+			record = strings.TrimSpace(r)
+		}
+	}
+	if record == "" {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusWarning,
+			Message:   "MTA-STS not configured",
+			Outcome:   "missing",
+			Details:   fmt.Sprintf("Without a TXT record at %s and a policy at https://mta-sts.%s/.well-known/mta-sts.txt, inbound mail can be downgraded to plaintext", name, domain),
+			Timestamp: timestamp,
+		}
+	}
+	if parseTagList(record)["id"] == "" {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusFail,
+			Message:   "MTA-STS record is invalid",
+			Outcome:   "invalid-record",
+			Details:   fmt.Sprintf("%s: the id tag is required", record),
+			Timestamp: timestamp,
+		}
+	}
+
+	policy, err := fetchMTASTSPolicy(domain)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusFail,
+			Message:   "MTA-STS policy unavailable",
+			Outcome:   "policy-unavailable",
+			Details:   fmt.Sprintf("%s is published but the policy could not be fetched: %v", name, err),
+			Evidence:  map[string]any{"record": record},
+			Timestamp: timestamp,
+		}
+	}
+
+	var problems, warnings []string
+	if policy.Version != "STSv1" {
+		problems = append(problems, fmt.Sprintf("version %q must be STSv1", policy.Version))
+	}
+	switch policy.Mode {
+	case "enforce":
+	case "testing":
+		warnings = append(warnings, "mode is testing; failures are reported but mail is still delivered")
+	case "none":
+		warnings = append(warnings, "mode is none; the policy is disabled")
+	default:
+		problems = append(problems, fmt.Sprintf("mode %q must be enforce, testing or none", policy.Mode))
+	}
+	if policy.MaxAge <= 0 || policy.MaxAge > 31557600 {
+		problems = append(problems, fmt.Sprintf("max_age %d must be between 1 and 31557600", policy.MaxAge))
+	} else if policy.MaxAge < 86400 {
+		warnings = append(warnings, "max_age below one day gives little protection between policy fetches")
+	}
+	for _, record := range mx {
+		host := strings.ToLower(strings.TrimSuffix(record.Host, "."))
+		if !mtaSTSCoversHost(policy.MX, host) {
+			problems = append(problems, fmt.Sprintf("MX host %s is not matched by the policy", host))
+		}
+	}
+
+	evidence := map[string]any{"record": record, "policy": policy}
+	details := fmt.Sprintf("Policy at %s, mode %s, max_age %d", policy.URL, policy.Mode, policy.MaxAge)
+
+	if len(problems) > 0 {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusFail,
+			Message:   "MTA-STS policy is invalid",
+			Outcome:   "invalid-policy",
+			Details:   details + ". Problems: " + strings.Join(append(problems, warnings...), "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+	if len(warnings) > 0 {
+		return models.CheckResult{
+			Name:      "MTA-STS",
+			Status:    models.StatusWarning,
+			Message:   "MTA-STS policy is not enforced",
+			Details:   details + ". Recommendations: " + strings.Join(warnings, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "MTA-STS",
+		Status:    models.StatusPass,
+		Message:   "MTA-STS policy enforced",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// fetchMTASTSPolicy downloads and parses https://mta-sts.<domain>/.well-known/mta-sts.txt
+func fetchMTASTSPolicy(domain string) (MTASTSPolicy, error) {
+	policy := MTASTSPolicy{URL: fmt.Sprintf("https://mta-sts.%s/.well-known/mta-sts.txt", domain)}
+
+	resp, err := mtaSTSClient.Get(policy.URL)
+	if err != nil {
+		return policy, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return policy, fmt.Errorf("HTTP %d response", resp.StatusCode)
+	}
+	if contentType := strings.ToLower(resp.Header.Get("Content-Type")); !strings.HasPrefix(contentType, "text/plain") {
+		return policy, fmt.Errorf("content type %q must be text/plain", contentType)
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, 64*1024))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "version":
+			policy.Version = value
+		case "mode":
+			policy.Mode = value
+		case "mx":
+			policy.MX = append(policy.MX, strings.ToLower(value))
+		case "max_age":
+			policy.MaxAge, _ = strconv.Atoi(value)
+		}
+	}
+
+	return policy, scanner.Err()
+}
+
+// mtaSTSCoversHost reports whether host matches one of the policy's mx patterns;
+// a leading "*." matches exactly one label
+func mtaSTSCoversHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if pattern == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if label, rest, found := strings.Cut(host, "."); found && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkTLSRPT validates the SMTP TLS reporting record (RFC 8460)
+func checkTLSRPT(resolver Resolver, domain string, receivesMail bool, timestamp time.Time) models.CheckResult {
+	name := "_smtp._tls." + domain
+	txt, err := lookupTXT(resolver, name)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "TLS-RPT",
+			Status:    models.StatusFail,
+			Message:   "DNS lookup failed",
+			Outcome:   "lookup-failed",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	var record string
+	for _, r := range txt {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(r)), "v=tlsrptv1") {
+			record = strings.TrimSpace(r)
+		}
+	}
+
+	if record == "" {
+		status := models.StatusWarning
+		if !receivesMail {
+			status = models.StatusPass
+		}
+		return models.CheckResult{
+			Name:      "TLS-RPT",
+			Status:    status,
+			Message:   "TLS reporting not configured",
+			Details:   fmt.Sprintf("Publish \"v=TLSRPTv1; rua=mailto:tls-reports@%s\" at %s to learn about failed TLS deliveries", domain, name),
+			Timestamp: timestamp,
+		}
+	}
+
+	var invalid []string
+	rua := parseTagList(record)["rua"]
+	for _, uri := range strings.Split(rua, ",") {
+		uri = strings.TrimSpace(uri)
+		if !strings.HasPrefix(uri, "mailto:") && !strings.HasPrefix(uri, "https://") {
+			invalid = append(invalid, uri)
+		}
+	}
+
+	if rua == "" || len(invalid) > 0 {
+		return models.CheckResult{
+			Name:      "TLS-RPT",
+			Status:    models.StatusFail,
+			Message:   "TLS-RPT record is invalid",
+			Details:   fmt.Sprintf("%s: rua must list mailto: or https:// report destinations", record),
+			Evidence:  map[string]any{"record": record},
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "TLS-RPT",
+		Status:    models.StatusPass,
+		Message:   "TLS reporting configured",
+		Details:   record,
+		Evidence:  map[string]any{"record": record},
+		Timestamp: timestamp,
+	}
+}
+
+// parseTagList parses "k=v; k=v" records used by DMARC, DKIM, MTA-STS and TLS-RPT; keys are lower-cased
+func parseTagList(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(record, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return tags
+}
@@ -0,0 +1,284 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// stubResolver answers lookups from fixed records; names it doesn't know don't exist
+type stubResolver struct {
+	txt map[string][]string
+	mx  map[string][]*net.MX
+}
+
+func (r stubResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if records, ok := r.txt[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r stubResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestCountSPFLookups(t *testing.T) {
+	tests := []struct {
+		name     string
+		txt      map[string][]string
+		lookups  int
+		problems []string // Substrings expected in the problems, in order
+	}{
+		{
+			name:    "mechanisms without lookups",
+			txt:     map[string][]string{"example.com": {"v=spf1 ip4:192.0.2.1 ip6:2001:db8::1 -all"}},
+			lookups: 0,
+		},
+		{
+			name:    "a, mx, ptr and exists count once each",
+			txt:     map[string][]string{"example.com": {"v=spf1 a mx:mail.example.com ptr exists:%{i}.example.com -all"}},
+			lookups: 4,
+		},
+		{
+			name: "nested includes and redirect",
+			txt: map[string][]string{
+				"example.com":   {"v=spf1 include:a.example.net redirect=b.example.net"},
+				"a.example.net": {"v=spf1 a mx -all"},
+				"b.example.net": {"v=spf1 include:c.example.net -all"},
+				"c.example.net": {"v=spf1 ip4:192.0.2.0/24 -all"},
+			},
+			lookups: 5,
+		},
+		{
+			name: "domain included from two branches counts on both",
+			txt: map[string][]string{
+				"example.com":        {"v=spf1 include:a.example.net include:b.example.net -all"},
+				"a.example.net":      {"v=spf1 include:shared.example.net -all"},
+				"b.example.net":      {"v=spf1 include:shared.example.net -all"},
+				"shared.example.net": {"v=spf1 a mx -all"},
+			},
+			lookups: 8,
+		},
+		{
+			name: "include loop",
+			txt: map[string][]string{
+				"example.com":   {"v=spf1 include:a.example.net -all"},
+				"a.example.net": {"v=spf1 include:example.com -all"},
+			},
+			lookups:  2,
+			problems: []string{"example.com includes itself in a loop (referenced from a.example.net)"},
+		},
+		{
+			name:     "void lookup of a missing include",
+			txt:      map[string][]string{"example.com": {"v=spf1 include:gone.example.net -all"}},
+			lookups:  1,
+			problems: []string{"gone.example.net (referenced from example.com) has 0 SPF records"},
+		},
+		{
+			name: "include without an SPF record",
+			txt: map[string][]string{
+				"example.com":   {"v=spf1 include:a.example.net -all"},
+				"a.example.net": {"google-site-verification=abc"},
+			},
+			lookups:  1,
+			problems: []string{"a.example.net (referenced from example.com) has 0 SPF records"},
+		},
+		{
+			name:    "macro targets are counted but not followed",
+			txt:     map[string][]string{"example.com": {"v=spf1 include:%{d}.spf.example.net -all"}},
+			lookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := stubResolver{txt: tt.txt}
+			record := tt.txt["example.com"][0]
+
+			lookups, problems := countSPFLookups(resolver, "example.com", record, map[string]bool{"example.com": true}, 0)
+			if lookups != tt.lookups {
+				t.Errorf("lookups = %d, want %d", lookups, tt.lookups)
+			}
+			if len(problems) != len(tt.problems) {
+				t.Fatalf("problems = %q, want %d matching %q", problems, len(tt.problems), tt.problems)
+			}
+			for i, want := range tt.problems {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, problems[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSPF(t *testing.T) {
+	// Eleven single-lookup includes push the record over the limit of ten
+	overLimit := map[string][]string{"example.com": {"v=spf1 " + strings.Repeat("a ", spfLookupLimit+1) + "-all"}}
+
+	tests := []struct {
+		name    string
+		txt     map[string][]string
+		status  models.Status
+		outcome string
+		details string
+	}{
+		{
+			name:    "missing record",
+			txt:     map[string][]string{"example.com": {"google-site-verification=abc"}},
+			status:  models.StatusFail,
+			outcome: "missing",
+		},
+		{
+			name:    "multiple records",
+			txt:     map[string][]string{"example.com": {"v=spf1 -all", "v=spf1 mx -all"}},
+			status:  models.StatusFail,
+			outcome: "multiple-records",
+		},
+		{
+			name:    "too many lookups",
+			txt:     overLimit,
+			status:  models.StatusFail,
+			details: "11 DNS lookups exceeds the limit of 10",
+		},
+		{
+			name:    "pass all",
+			txt:     map[string][]string{"example.com": {"v=spf1 mx +all"}},
+			status:  models.StatusFail,
+			details: "\"+all\" authorises every server",
+		},
+		{
+			name:    "unknown mechanism",
+			txt:     map[string][]string{"example.com": {"v=spf1 mx foo:bar -all"}},
+			status:  models.StatusFail,
+			details: "unknown mechanism \"foo:bar\"",
+		},
+		{
+			name:    "neutral all",
+			txt:     map[string][]string{"example.com": {"v=spf1 mx ?all"}},
+			status:  models.StatusWarning,
+			details: "\"?all\" is neutral",
+		},
+		{
+			name:    "no all mechanism",
+			txt:     map[string][]string{"example.com": {"v=spf1 mx"}},
+			status:  models.StatusWarning,
+			details: "no \"all\" mechanism",
+		},
+		{
+			name:    "strict record",
+			txt:     map[string][]string{"example.com": {"v=spf1 mx include:_spf.example.net -all"}, "_spf.example.net": {"v=spf1 ip4:192.0.2.0/24 -all"}},
+			status:  models.StatusPass,
+			details: "(2 of 10 DNS lookups)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkSPF(stubResolver{txt: tt.txt}, "example.com", time.Now())
+			if result.Status != tt.status {
+				t.Errorf("status = %s, want %s (%s: %s)", result.Status, tt.status, result.Message, result.Details)
+			}
+			if result.Outcome != tt.outcome {
+				t.Errorf("outcome = %q, want %q", result.Outcome, tt.outcome)
+			}
+			if !strings.Contains(result.Details, tt.details) {
+				t.Errorf("details = %q, want it to contain %q", result.Details, tt.details)
+			}
+		})
+	}
+}
+
+func TestCheckDMARC(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []string
+		status  models.Status
+		outcome string
+		message string
+	}{
+		{
+			name:    "missing record",
+			records: nil,
+			status:  models.StatusFail,
+			outcome: "missing",
+			message: "Missing DMARC record",
+		},
+		{
+			name:    "multiple records",
+			records: []string{"v=DMARC1; p=reject", "v=DMARC1; p=none"},
+			status:  models.StatusFail,
+			outcome: "multiple-records",
+			message: "Multiple DMARC records",
+		},
+		{
+			name:    "missing policy",
+			records: []string{"v=DMARC1; rua=mailto:dmarc@example.com"},
+			status:  models.StatusFail,
+			message: "DMARC record has no valid policy",
+		},
+		{
+			name:    "unknown policy",
+			records: []string{"v=DMARC1; p=block; rua=mailto:dmarc@example.com"},
+			status:  models.StatusFail,
+			message: "DMARC record has no valid policy",
+		},
+		{
+			name:    "monitoring only",
+			records: []string{"v=DMARC1; p=none; rua=mailto:dmarc@example.com"},
+			status:  models.StatusWarning,
+			message: "DMARC policy p=none could be stronger",
+		},
+		{
+			name:    "partial enforcement",
+			records: []string{"v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com"},
+			status:  models.StatusWarning,
+			message: "DMARC policy p=quarantine could be stronger",
+		},
+		{
+			name:    "unprotected subdomains",
+			records: []string{"v=DMARC1; p=reject; sp=none; rua=mailto:dmarc@example.com"},
+			status:  models.StatusWarning,
+			message: "DMARC policy p=reject could be stronger",
+		},
+		{
+			name:    "no aggregate reports",
+			records: []string{"v=DMARC1; p=reject"},
+			status:  models.StatusWarning,
+			message: "DMARC policy p=reject could be stronger",
+		},
+		{
+			name:    "enforced with mixed case and spacing",
+			records: []string{"  v=DMARC1;P=Reject ; rua=mailto:dmarc@example.com", "some other TXT record"},
+			status:  models.StatusPass,
+			message: "DMARC policy p=reject is enforced",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txt := map[string][]string{}
+			if tt.records != nil {
+				txt["_dmarc.example.com"] = tt.records
+			}
+
+			result := checkDMARC(stubResolver{txt: txt}, "example.com", time.Now())
+			if result.Status != tt.status {
+				t.Errorf("status = %s, want %s (%s: %s)", result.Status, tt.status, result.Message, result.Details)
+			}
+			if result.Outcome != tt.outcome {
+				t.Errorf("outcome = %q, want %q", result.Outcome, tt.outcome)
+			}
+			if result.Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package checker
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+//go:embed data/sensitive_paths.json
+var defaultSensitivePaths []byte
+
+// nonSlugChars are replaced when a probed path becomes part of a finding ID
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxExposureBody limits how much of each probed response is read for fingerprinting
+const maxExposureBody = 64 * 1024
+
+// probeClient is used by the active probes; it doesn't follow redirects so a redirect
+// to a login page or error handler is never mistaken for exposed content
+var probeClient = &http.Client{
+	Timeout: 15 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// ExposurePath describes a well-known leaky path and how to recognise its content
+type ExposurePath struct {
+	Path  string   `json:"path"`
+	Name  string   `json:"name"`
+	Match []string `json:"match,omitempty"` // Regular expressions, any of which identifies the content
+	Magic string   `json:"magic,omitempty"` // Hex-encoded leading bytes for binary formats
+
+	patterns []*regexp.Regexp
+	magic    []byte
+}
+
+// DefaultExposurePaths returns the curated list of sensitive paths embedded in the binary
+func DefaultExposurePaths() []ExposurePath {
+	paths, err := parseExposurePaths(defaultSensitivePaths)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded sensitive path list: %v", err))
+	}
+	return paths
+}
+
+// LoadExposurePaths reads additional sensitive paths from a JSON file using the same format as the embedded list
+func LoadExposurePaths(filename string) ([]ExposurePath, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sensitive path list: %w", err)
+	}
+	return parseExposurePaths(data)
+}
+
+// parseExposurePaths decodes and compiles a sensitive path list
+func parseExposurePaths(data []byte) ([]ExposurePath, error) {
+	var paths []ExposurePath
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, fmt.Errorf("failed to parse sensitive path list: %w", err)
+	}
+
+	for i := range paths {
+		p := &paths[i]
+		if !strings.HasPrefix(p.Path, "/") {
+			return nil, fmt.Errorf("path %q must start with /", p.Path)
+		}
+		if len(p.Match) == 0 && p.Magic == "" {
+			return nil, fmt.Errorf("path %q needs a match pattern or magic bytes", p.Path)
+		}
+		for _, pattern := range p.Match {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for %s: %w", p.Path, err)
+			}
+			p.patterns = append(p.patterns, re)
+		}
+		if p.Magic != "" {
+			magic, err := hex.DecodeString(p.Magic)
+			if err != nil {
+				return nil, fmt.Errorf("invalid magic bytes for %s: %w", p.Path, err)
+			}
+			p.magic = magic
+		}
+	}
+
+	return paths, nil
+}
+
+// matches reports whether body carries this path's content fingerprint
+func (p ExposurePath) matches(body []byte) bool {
+	if len(p.magic) > 0 && bytes.HasPrefix(body, p.magic) {
+		return true
+	}
+	for _, re := range p.patterns {
+		if re.Match(body) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSensitiveFiles probes well-known leaky paths on the site, waiting delay between requests.
+// A path is only reported when it answers 200 with content matching its fingerprint and the site's
+// soft-404 page does not match the same fingerprint.
+func CheckSensitiveFiles(baseURL string, paths []ExposurePath, delay time.Duration) []models.CheckResult {
+	start := time.Now()
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Sensitive File Exposure",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+	origin := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+
+	// Fetch a path that cannot exist to learn what the site's not-found response looks like
+	baseline, err := fetchSoft404Baseline(origin)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Sensitive File Exposure",
+			Status:    models.StatusFail,
+			Message:   "Failed to probe site",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	var results []models.CheckResult
+	var failures []string
+	probed := 0
+
+	for i, p := range paths {
+		if i > 0 && delay > 0 {
+			time.Sleep(delay)
+		}
+
+		target := origin + p.Path
+		status, body, err := fetchProbe(target)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", p.Path, err))
+			continue
+		}
+		probed++
+
+		if status != http.StatusOK || !p.matches(body) || p.matches(baseline) {
+			continue
+		}
+
+		results = append(results, models.CheckResult{
+			Name:    fmt.Sprintf("Sensitive File Exposure (%s)", p.Path),
+			Status:  models.StatusFail,
+			Message: fmt.Sprintf("%s publicly accessible", p.Name),
+			Outcome: pathOutcome(p.Path),
+			Details: fmt.Sprintf("%s returned HTTP 200 with content matching %s. Remove the file or block access at the web server", target, p.Name),
+			Evidence: map[string]any{
+				"url":    target,
+				"path":   p.Path,
+				"status": status,
+				"bytes":  len(body),
+			},
+			Timestamp: start,
+		})
+	}
+
+	if len(results) > 0 {
+		return results
+	}
+
+	details := fmt.Sprintf("Probed %d of %d well-known sensitive paths on %s", probed, len(paths), origin)
+	if len(failures) > 0 {
+		return []models.CheckResult{{
+			Name:      "Sensitive File Exposure",
+			Status:    models.StatusWarning,
+			Message:   "Some sensitive paths could not be probed",
+			Details:   details + ". Errors: " + strings.Join(failures, "; "),
+			Timestamp: start,
+		}}
+	}
+
+	return []models.CheckResult{{
+		Name:      "Sensitive File Exposure",
+		Status:    models.StatusPass,
+		Message:   "No sensitive files exposed",
+		Details:   details,
+		Timestamp: start,
+	}}
+}
+
+// fetchSoft404Baseline requests a random non-existent path and returns its body
+func fetchSoft404Baseline(origin string) ([]byte, error) {
+	token, err := probeToken()
+	if err != nil {
+		return nil, err
+	}
+	_, body, err := fetchProbe(fmt.Sprintf("%s/checkly-%s", origin, token))
+	return body, err
+}
+
+// fetchProbe issues a GET without following redirects and returns the status and the start of the body
+func fetchProbe(target string) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxExposureBody))
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+// pathOutcome turns a probed path into the outcome of its finding, e.g. /.git/HEAD becomes git-head,
+// so every exposed path has its own stable ID
+func pathOutcome(path string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(path), "-"), "-")
+}
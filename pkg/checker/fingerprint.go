@@ -0,0 +1,257 @@
+package checker
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+//go:embed data/technologies.json
+var defaultTechnologyRules []byte
+
+// TechnologyRule describes how to recognise one technology. Every pattern is a regular
+// expression whose first capture group, when present, is the version; an empty header
+// pattern matches on the header's presence alone.
+type TechnologyRule struct {
+	Name     string            `json:"name"`
+	Category string            `json:"category"`
+	Headers  map[string]string `json:"headers,omitempty"` // Response header name to value pattern
+	Cookies  []string          `json:"cookies,omitempty"` // Patterns matched against Set-Cookie names
+	Meta     map[string]string `json:"meta,omitempty"`    // Meta tag name to content pattern
+	Scripts  []string          `json:"scripts,omitempty"` // Patterns matched against script URLs
+	HTML     []string          `json:"html,omitempty"`    // Patterns matched against the raw page
+
+	headerPatterns map[string]*regexp.Regexp
+	cookiePatterns []*regexp.Regexp
+	metaPatterns   map[string]*regexp.Regexp
+	scriptPatterns []*regexp.Regexp
+	htmlPatterns   []*regexp.Regexp
+}
+
+// DefaultTechnologyRules returns the fingerprinting rules embedded in the binary
+func DefaultTechnologyRules() []TechnologyRule {
+	rules, err := parseTechnologyRules(defaultTechnologyRules)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded technology rules: %v", err))
+	}
+	return rules
+}
+
+// LoadTechnologyRules reads fingerprinting rules from a JSON file, replacing the embedded ones
+func LoadTechnologyRules(filename string) ([]TechnologyRule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read technology rules: %w", err)
+	}
+	return parseTechnologyRules(data)
+}
+
+// parseTechnologyRules decodes and compiles a rule file
+func parseTechnologyRules(data []byte) ([]TechnologyRule, error) {
+	var rules []TechnologyRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse technology rules: %w", err)
+	}
+
+	compile := func(rule string, pattern string) (*regexp.Regexp, error) {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for %s: %w", rule, err)
+		}
+		return re, nil
+	}
+
+	for i := range rules {
+		r := &rules[i]
+		if r.Name == "" || r.Category == "" {
+			return nil, fmt.Errorf("rule %d needs a name and a category", i)
+		}
+
+		r.headerPatterns = make(map[string]*regexp.Regexp)
+		for header, pattern := range r.Headers {
+			re, err := compile(r.Name, pattern)
+			if err != nil {
+				return nil, err
+			}
+			r.headerPatterns[http.CanonicalHeaderKey(header)] = re
+		}
+		r.metaPatterns = make(map[string]*regexp.Regexp)
+		for name, pattern := range r.Meta {
+			re, err := compile(r.Name, pattern)
+			if err != nil {
+				return nil, err
+			}
+			r.metaPatterns[strings.ToLower(name)] = re
+		}
+		for _, list := range []struct {
+			patterns []string
+			target   *[]*regexp.Regexp
+		}{
+			{r.Cookies, &r.cookiePatterns},
+			{r.Scripts, &r.scriptPatterns},
+			{r.HTML, &r.htmlPatterns},
+		} {
+			for _, pattern := range list.patterns {
+				re, err := compile(r.Name, pattern)
+				if err != nil {
+					return nil, err
+				}
+				*list.target = append(*list.target, re)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// DetectTechnologies fingerprints a page from its response headers and, when available, its HTML.
+// Pass nil rules to use the embedded rule file.
+func DetectTechnologies(rules []TechnologyRule, pageURL string, headers http.Header, htmlContent string) []models.Technology {
+	if rules == nil {
+		rules = DefaultTechnologyRules()
+	}
+
+	var cookies []string
+	for _, cookie := range headers.Values("Set-Cookie") {
+		name, _, _ := strings.Cut(cookie, "=")
+		cookies = append(cookies, strings.TrimSpace(name))
+	}
+
+	meta := make(map[string]string)
+	var scripts []string
+	if htmlContent != "" {
+		if doc, err := html.Parse(strings.NewReader(htmlContent)); err == nil {
+			meta = extractMetaTags(doc)
+			base, _ := url.Parse(pageURL)
+			for _, res := range extractSubresources(doc, base) {
+				if res.Kind == "script" {
+					scripts = append(scripts, res.URL)
+				}
+			}
+		}
+	}
+
+	var detected []models.Technology
+	for _, rule := range rules {
+		tech := models.Technology{Name: rule.Name, Category: rule.Category}
+		match := func(source string, m []string) {
+			tech.Sources = append(tech.Sources, source)
+			if tech.Version == "" && len(m) > 1 {
+				tech.Version = m[1]
+			}
+		}
+
+		for header, re := range rule.headerPatterns {
+			for _, value := range headers.Values(header) {
+				if m := re.FindStringSubmatch(value); m != nil {
+					match("header:"+header, m)
+					break
+				}
+			}
+		}
+		for _, re := range rule.cookiePatterns {
+			for _, name := range cookies {
+				if re.MatchString(name) {
+					match("cookie:"+name, nil)
+					break
+				}
+			}
+		}
+		for name, re := range rule.metaPatterns {
+			if content, ok := meta[name]; ok {
+				if m := re.FindStringSubmatch(content); m != nil {
+					match("meta:"+name, m)
+				}
+			}
+		}
+		for _, re := range rule.scriptPatterns {
+			for _, src := range scripts {
+				if m := re.FindStringSubmatch(src); m != nil {
+					match("script:"+src, m)
+					break
+				}
+			}
+		}
+		for _, re := range rule.htmlPatterns {
+			if m := re.FindStringSubmatch(htmlContent); m != nil {
+				match("html", m)
+				break
+			}
+		}
+
+		if len(tech.Sources) > 0 {
+			sort.Strings(tech.Sources)
+			detected = append(detected, tech)
+		}
+	}
+
+	sort.Slice(detected, func(i, j int) bool {
+		if detected[i].Category != detected[j].Category {
+			return detected[i].Category < detected[j].Category
+		}
+		return detected[i].Name < detected[j].Name
+	})
+
+	return detected
+}
+
+// DetectTechnologiesFromURL fetches a page and fingerprints it
+func DetectTechnologiesFromURL(pageURL string, rules []TechnologyRule) ([]models.Technology, error) {
+	resp, err := http.Get(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Error pages still carry identifying headers, so only the body is discarded
+	htmlContent := ""
+	if resp.StatusCode < 400 {
+		htmlContent = string(body)
+	}
+
+	return DetectTechnologies(rules, pageURL, resp.Header, htmlContent), nil
+}
+
+// extractMetaTags maps lower-cased meta names to their content
+func extractMetaTags(doc *html.Node) map[string]string {
+	meta := make(map[string]string)
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			var name, content string
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "name":
+					name = strings.ToLower(attr.Val)
+				case "content":
+					content = attr.Val
+				}
+			}
+			if _, seen := meta[name]; name != "" && !seen {
+				meta[name] = content
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+
+	traverse(doc)
+	return meta
+}
@@ -0,0 +1,209 @@
+package checker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// hstsPreloadMinMaxAge is the minimum max-age accepted by the HSTS preload list
+const hstsPreloadMinMaxAge = 31536000
+
+// PreloadRequirement is one of the HSTS preload list submission requirements
+type PreloadRequirement struct {
+	Requirement string `json:"requirement"`
+	Met         bool   `json:"met"`
+	Details     string `json:"details"`
+}
+
+// CheckHSTSPreload evaluates whether the registrable domain behind targetURL meets every
+// submission requirement of the HSTS preload list (hstspreload.org)
+func CheckHSTSPreload(targetURL string) models.CheckResult {
+	start := time.Now()
+
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Hostname() == "" {
+		return models.CheckResult{
+			Name:      "HSTS Preload Eligibility",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   fmt.Sprintf("Could not determine a host from %q", targetURL),
+			Timestamp: start,
+		}
+	}
+	if net.ParseIP(u.Hostname()) != nil {
+		return models.CheckResult{
+			Name:      "HSTS Preload Eligibility",
+			Status:    models.StatusWarning,
+			Message:   "Not applicable to IP addresses",
+			Outcome:   "ip-address",
+			Details:   "Only domain names can be added to the HSTS preload list",
+			Timestamp: start,
+		}
+	}
+	apex := registrableDomain(u.Hostname())
+
+	var requirements []PreloadRequirement
+	requirements = append(requirements, checkPreloadHTTPRedirect(apex))
+
+	httpsRequirements, preloadRequested := checkPreloadHTTPS(apex)
+	requirements = append(requirements, httpsRequirements...)
+
+	var failed []string
+	for _, req := range requirements {
+		if !req.Met {
+			failed = append(failed, fmt.Sprintf("%s (%s)", req.Requirement, req.Details))
+		}
+	}
+
+	evidence := map[string]any{"domain": apex, "requirements": requirements}
+
+	if len(failed) == 0 {
+		return models.CheckResult{
+			Name:      "HSTS Preload Eligibility",
+			Status:    models.StatusPass,
+			Message:   "Eligible for the HSTS preload list",
+			Details:   fmt.Sprintf("%s meets all %d submission requirements; submit it at https://hstspreload.org", apex, len(requirements)),
+			Evidence:  evidence,
+			Timestamp: start,
+		}
+	}
+
+	// Asking for preload without qualifying is a misconfiguration; not asking is just a missed opportunity
+	status := models.StatusWarning
+	message := "Not eligible for the HSTS preload list"
+	if preloadRequested {
+		status = models.StatusFail
+		message = "HSTS preload requested but requirements not met"
+	}
+
+	return models.CheckResult{
+		Name:      "HSTS Preload Eligibility",
+		Status:    status,
+		Message:   message,
+		Details:   fmt.Sprintf("%s fails %d of %d requirements: %s", apex, len(failed), len(requirements), strings.Join(failed, "; ")),
+		Evidence:  evidence,
+		Timestamp: start,
+	}
+}
+
+// checkPreloadHTTPRedirect verifies http://apex redirects to https://apex before going anywhere else
+func checkPreloadHTTPRedirect(apex string) PreloadRequirement {
+	req := PreloadRequirement{Requirement: "HTTP redirects to HTTPS on the same host"}
+
+	resp, err := probeClient.Get("http://" + apex + "/")
+	if err != nil {
+		// Only a refused connection shows nothing listens; timeouts and DNS failures stay unmet
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			req.Met = true
+			req.Details = "port 80 is not listening"
+			return req
+		}
+		req.Details = err.Error()
+		return req
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		req.Details = fmt.Sprintf("http://%s/ answered HTTP %d instead of redirecting", apex, resp.StatusCode)
+		return req
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		req.Details = fmt.Sprintf("HTTP %d redirect has no usable Location header", resp.StatusCode)
+		return req
+	}
+	if location.Scheme != "https" || !strings.EqualFold(location.Hostname(), apex) {
+		req.Details = fmt.Sprintf("redirects to %s; it must go to https://%s first", location, apex)
+		return req
+	}
+
+	req.Met = true
+	req.Details = fmt.Sprintf("redirects to %s", location)
+	return req
+}
+
+// checkPreloadHTTPS checks the certificate and the HSTS header served on https://apex. The header is read
+// from the first response, so a redirect must carry it too. It also reports whether preload was requested.
+func checkPreloadHTTPS(apex string) ([]PreloadRequirement, bool) {
+	certificate := PreloadRequirement{Requirement: "Valid certificate"}
+	header := PreloadRequirement{Requirement: "HSTS header on the HTTPS apex response"}
+	maxAgeReq := PreloadRequirement{Requirement: fmt.Sprintf("max-age of at least %d seconds", hstsPreloadMinMaxAge)}
+	subdomains := PreloadRequirement{Requirement: "includeSubDomains directive"}
+	preloadReq := PreloadRequirement{Requirement: "preload directive"}
+	all := func() []PreloadRequirement {
+		return []PreloadRequirement{certificate, header, maxAgeReq, subdomains, preloadReq}
+	}
+
+	resp, err := probeClient.Get("https://" + apex + "/")
+	if err != nil {
+		if isCertificateError(err) {
+			certificate.Details = err.Error()
+		} else {
+			certificate.Details = "HTTPS request failed: " + err.Error()
+		}
+		for _, req := range []*PreloadRequirement{&header, &maxAgeReq, &subdomains, &preloadReq} {
+			req.Details = "not checked, HTTPS is unavailable"
+		}
+		return all(), false
+	}
+	resp.Body.Close()
+
+	certificate.Met = true
+	certificate.Details = "certificate chain verified"
+
+	hsts := resp.Header.Get("Strict-Transport-Security")
+	where := fmt.Sprintf("https://%s/", apex)
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		where = fmt.Sprintf("the HTTP %d redirect from https://%s/", resp.StatusCode, apex)
+	}
+
+	if hsts == "" {
+		header.Details = fmt.Sprintf("%s has no Strict-Transport-Security header", where)
+		for _, req := range []*PreloadRequirement{&maxAgeReq, &subdomains, &preloadReq} {
+			req.Details = "header missing"
+		}
+		return all(), false
+	}
+	header.Met = true
+	header.Details = fmt.Sprintf("%s sends %q", where, hsts)
+
+	maxAge, includeSubDomains, preload := parseHSTS(hsts)
+
+	maxAgeReq.Met = maxAge >= hstsPreloadMinMaxAge
+	maxAgeReq.Details = fmt.Sprintf("max-age=%d", maxAge)
+
+	subdomains.Met = includeSubDomains
+	subdomains.Details = "present"
+	if !includeSubDomains {
+		subdomains.Details = "missing; every subdomain must be served over HTTPS before adding it"
+	}
+
+	preloadReq.Met = preload
+	preloadReq.Details = "present"
+	if !preload {
+		preloadReq.Details = "missing"
+	}
+
+	return all(), preload
+}
+
+// isCertificateError reports whether err was caused by certificate verification
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var verification *tls.CertificateVerificationError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) ||
+		errors.As(err, &invalid) || errors.As(err, &verification)
+}
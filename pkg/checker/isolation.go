@@ -0,0 +1,491 @@
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// powerfulFeatures lists Permissions-Policy features that grant access to sensitive capabilities
+var powerfulFeatures = []string{
+	"camera",
+	"microphone",
+	"geolocation",
+	"payment",
+	"usb",
+	"serial",
+	"hid",
+	"bluetooth",
+	"midi",
+	"display-capture",
+	"clipboard-read",
+	"xr-spatial-tracking",
+}
+
+// knownFeatures contains the policy-controlled features recognised by current browsers
+var knownFeatures = map[string]bool{
+	"accelerometer": true, "ambient-light-sensor": true, "attribution-reporting": true,
+	"autoplay": true, "bluetooth": true, "browsing-topics": true, "camera": true,
+	"captured-surface-control": true, "ch-ua": true, "clipboard-read": true,
+	"clipboard-write": true, "compute-pressure": true, "cross-origin-isolated": true,
+	"display-capture": true, "document-domain": true, "encrypted-media": true,
+	"execution-while-not-rendered": true, "execution-while-out-of-viewport": true,
+	"fullscreen": true, "gamepad": true, "geolocation": true, "gyroscope": true,
+	"hid": true, "identity-credentials-get": true, "idle-detection": true,
+	"interest-cohort": true, "join-ad-interest-group": true, "keyboard-map": true,
+	"local-fonts": true, "magnetometer": true, "microphone": true, "midi": true,
+	"otp-credentials": true, "payment": true, "picture-in-picture": true,
+	"publickey-credentials-create": true, "publickey-credentials-get": true,
+	"run-ad-auction": true, "screen-wake-lock": true, "serial": true,
+	"speaker-selection": true, "storage-access": true, "sync-xhr": true,
+	"usb": true, "web-share": true, "window-management": true, "xr-spatial-tracking": true,
+}
+
+// featureNamePattern matches a structured-header token usable as a feature name
+var featureNamePattern = regexp.MustCompile(`^[a-z*][a-z0-9_\-.*]*$`)
+
+// validClearSiteDataDirectives are the quoted directives defined for Clear-Site-Data
+var validClearSiteDataDirectives = map[string]bool{
+	`"cache"`: true, `"cookies"`: true, `"storage"`: true, `"executioncontexts"`: true,
+	`"clienthints"`: true, `"prefetchcache"`: true, `"prerendercache"`: true, `"*"`: true,
+}
+
+// checkPermissionsPolicy parses the Permissions-Policy header and flags powerful features left enabled
+func checkPermissionsPolicy(policy string, timestamp time.Time) models.CheckResult {
+	if policy == "" {
+		return models.CheckResult{
+			Name:      "Permissions Policy",
+			Status:    models.StatusWarning,
+			Message:   "Missing Permissions-Policy header",
+			Outcome:   "missing",
+			Details:   "Add Permissions-Policy to disable features the site doesn't use, e.g. camera=(), microphone=(), geolocation=()",
+			Timestamp: timestamp,
+		}
+	}
+
+	allowlists, syntaxErrors := parsePermissionsPolicy(policy)
+
+	var unknown []string
+	for feature := range allowlists {
+		if !knownFeatures[feature] {
+			unknown = append(unknown, feature)
+		}
+	}
+	sort.Strings(unknown)
+
+	var wildcard, undeclared, thirdParty []string
+	for _, feature := range powerfulFeatures {
+		allowlist, declared := allowlists[feature]
+		switch {
+		case !declared:
+			undeclared = append(undeclared, feature)
+		case containsString(allowlist, "*"):
+			wildcard = append(wildcard, feature)
+		case hasExternalOrigin(allowlist):
+			thirdParty = append(thirdParty, feature)
+		}
+	}
+
+	evidence := map[string]any{
+		"features":             allowlists,
+		"syntax_errors":        syntaxErrors,
+		"unknown_features":     unknown,
+		"wildcard_features":    wildcard,
+		"third_party_features": thirdParty,
+		"undeclared_features":  undeclared,
+	}
+
+	if len(syntaxErrors) > 0 {
+		return models.CheckResult{
+			Name:      "Permissions Policy",
+			Status:    models.StatusFail,
+			Message:   "Permissions-Policy header is malformed",
+			Outcome:   "malformed",
+			Details:   "Syntax errors: " + strings.Join(syntaxErrors, "; ") + ". Use the structured header syntax, e.g. camera=(), geolocation=(self)",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	// Undeclared features keep the browser defaults, which are mostly same-origin, so they are only
+	// noted; wildcard and third-party allowlists actively open features up
+	details := fmt.Sprintf("%d features declared", len(allowlists))
+	var notes []string
+	if len(undeclared) > 0 {
+		notes = append(notes, fmt.Sprintf("not declared: %s", strings.Join(undeclared, ", ")))
+	}
+	if len(unknown) > 0 {
+		notes = append(notes, fmt.Sprintf("unrecognised features: %s", strings.Join(unknown, ", ")))
+	}
+	if len(notes) > 0 {
+		details += ". Notes: " + strings.Join(notes, "; ")
+	}
+
+	if len(wildcard) > 0 {
+		return models.CheckResult{
+			Name:      "Permissions Policy",
+			Status:    models.StatusFail,
+			Message:   "Powerful features enabled for every origin",
+			Details:   fmt.Sprintf("Enabled for all origins: %s. %s", strings.Join(wildcard, ", "), details),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(thirdParty) > 0 {
+		return models.CheckResult{
+			Name:      "Permissions Policy",
+			Status:    models.StatusWarning,
+			Message:   "Powerful features delegated to other origins",
+			Details:   fmt.Sprintf("Delegated to other origins: %s. %s", strings.Join(thirdParty, ", "), details),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Permissions Policy",
+		Status:    models.StatusPass,
+		Message:   "No powerful features opened up by Permissions-Policy",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// parsePermissionsPolicy parses a Permissions-Policy structured dictionary into feature allowlists.
+// An empty allowlist means the feature is disabled everywhere.
+func parsePermissionsPolicy(policy string) (map[string][]string, []string) {
+	allowlists := make(map[string][]string)
+	var syntaxErrors []string
+
+	for _, member := range splitTopLevel(policy, ',') {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+
+		eq := strings.Index(member, "=")
+		if eq == -1 {
+			syntaxErrors = append(syntaxErrors, fmt.Sprintf("%q has no allowlist", member))
+			continue
+		}
+
+		feature := strings.TrimSpace(member[:eq])
+		value := strings.TrimSpace(member[eq+1:])
+		if !featureNamePattern.MatchString(feature) {
+			syntaxErrors = append(syntaxErrors, fmt.Sprintf("invalid feature name %q", feature))
+			continue
+		}
+
+		// Drop parameters such as ;report-to=endpoint
+		if strings.HasPrefix(value, "(") {
+			if end := strings.Index(value, ")"); end != -1 {
+				value = value[:end+1]
+			}
+		} else {
+			value = headerToken(value)
+		}
+
+		allowlist, err := parseAllowlist(value)
+		if err != "" {
+			syntaxErrors = append(syntaxErrors, fmt.Sprintf("%s: %s", feature, err))
+			continue
+		}
+		allowlists[feature] = allowlist
+	}
+
+	return allowlists, syntaxErrors
+}
+
+// parseAllowlist parses a single allowlist value: *, self, src, a quoted origin or an inner list of those
+func parseAllowlist(value string) ([]string, string) {
+	if strings.HasPrefix(value, "(") {
+		if !strings.HasSuffix(value, ")") {
+			return nil, "unterminated allowlist"
+		}
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		allowlist := []string{}
+		for _, item := range strings.Fields(inner) {
+			origin, err := parseAllowlistItem(item)
+			if err != "" {
+				return nil, err
+			}
+			allowlist = append(allowlist, origin)
+		}
+		return allowlist, ""
+	}
+
+	origin, err := parseAllowlistItem(value)
+	if err != "" {
+		return nil, err
+	}
+	return []string{origin}, ""
+}
+
+// parseAllowlistItem validates one allowlist member
+func parseAllowlistItem(item string) (string, string) {
+	switch item {
+	case "*", "self", "src":
+		return item, ""
+	case "'self'", "'none'", "'src'":
+		return "", fmt.Sprintf("%s uses Feature-Policy syntax", item)
+	}
+
+	if len(item) >= 2 && strings.HasPrefix(item, `"`) && strings.HasSuffix(item, `"`) {
+		origin := item[1 : len(item)-1]
+		if !strings.HasPrefix(origin, "https://") && !strings.HasPrefix(origin, "http://") {
+			return "", fmt.Sprintf("origin %s must include a scheme", item)
+		}
+		return origin, ""
+	}
+
+	return "", fmt.Sprintf("invalid allowlist value %s (origins must be quoted)", item)
+}
+
+// hasExternalOrigin reports whether an allowlist names an explicit origin
+func hasExternalOrigin(allowlist []string) bool {
+	for _, origin := range allowlist {
+		if origin != "self" && origin != "src" && origin != "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside parentheses or quotes
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	inQuotes := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '(' && !inQuotes:
+			depth++
+		case r == ')' && !inQuotes && depth > 0:
+			depth--
+		case r == sep && depth == 0 && !inQuotes:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	parts = append(parts, current.String())
+
+	return parts
+}
+
+// checkCrossOriginIsolation validates COOP and COEP and reports whether the page is cross-origin isolated
+func checkCrossOriginIsolation(coop string, coep string, timestamp time.Time) models.CheckResult {
+	coopValue := strings.ToLower(headerToken(coop))
+	coepValue := strings.ToLower(headerToken(coep))
+
+	var invalid []string
+	switch coopValue {
+	case "", "same-origin", "same-origin-allow-popups", "noopener-allow-popups", "unsafe-none":
+	default:
+		invalid = append(invalid, fmt.Sprintf("Cross-Origin-Opener-Policy: %s", coop))
+	}
+	switch coepValue {
+	case "", "require-corp", "credentialless", "unsafe-none":
+	default:
+		invalid = append(invalid, fmt.Sprintf("Cross-Origin-Embedder-Policy: %s", coep))
+	}
+
+	isolated := coopValue == "same-origin" && (coepValue == "require-corp" || coepValue == "credentialless")
+	evidence := map[string]any{
+		"coop":     coop,
+		"coep":     coep,
+		"isolated": isolated,
+	}
+
+	if len(invalid) > 0 {
+		return models.CheckResult{
+			Name:      "Cross-Origin Isolation",
+			Status:    models.StatusFail,
+			Message:   "Invalid cross-origin isolation headers",
+			Details:   "Invalid values: " + strings.Join(invalid, ", "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if isolated {
+		return models.CheckResult{
+			Name:      "Cross-Origin Isolation",
+			Status:    models.StatusPass,
+			Message:   "Page is cross-origin isolated",
+			Details:   fmt.Sprintf("COOP=%s, COEP=%s", coopValue, coepValue),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if coopValue == "" || coopValue == "unsafe-none" {
+		return models.CheckResult{
+			Name:      "Cross-Origin Isolation",
+			Status:    models.StatusWarning,
+			Message:   "Missing Cross-Origin-Opener-Policy",
+			Details:   "Add Cross-Origin-Opener-Policy: same-origin to isolate the browsing context from cross-origin popups",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	details := fmt.Sprintf("COOP=%s", coopValue)
+	if coepValue != "" {
+		details += fmt.Sprintf(", COEP=%s", coepValue)
+	}
+
+	return models.CheckResult{
+		Name:      "Cross-Origin Isolation",
+		Status:    models.StatusPass,
+		Message:   "Cross-Origin-Opener-Policy configured",
+		Details:   details + ". Add COEP: require-corp with COOP: same-origin only if cross-origin isolation is needed",
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkCrossOriginResourcePolicy validates the CORP header
+func checkCrossOriginResourcePolicy(corp string, timestamp time.Time) models.CheckResult {
+	if corp == "" {
+		return models.CheckResult{
+			Name:      "Cross-Origin-Resource-Policy",
+			Status:    models.StatusWarning,
+			Message:   "Missing Cross-Origin-Resource-Policy header",
+			Outcome:   "missing",
+			Details:   "Add Cross-Origin-Resource-Policy: same-origin or same-site to stop other sites embedding your resources",
+			Timestamp: timestamp,
+		}
+	}
+
+	switch strings.ToLower(headerToken(corp)) {
+	case "same-origin", "same-site":
+		return models.CheckResult{
+			Name:      "Cross-Origin-Resource-Policy",
+			Status:    models.StatusPass,
+			Message:   "Cross-Origin-Resource-Policy properly configured",
+			Details:   fmt.Sprintf("Set to %s", corp),
+			Timestamp: timestamp,
+		}
+	case "cross-origin":
+		return models.CheckResult{
+			Name:      "Cross-Origin-Resource-Policy",
+			Status:    models.StatusWarning,
+			Message:   "Cross-Origin-Resource-Policy allows any origin",
+			Details:   "Set to cross-origin. Use same-origin or same-site unless the resource is meant to be embedded elsewhere",
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Cross-Origin-Resource-Policy",
+		Status:    models.StatusFail,
+		Message:   "Cross-Origin-Resource-Policy has invalid value",
+		Details:   fmt.Sprintf("Invalid value: %s. Use same-origin, same-site or cross-origin", corp),
+		Timestamp: timestamp,
+	}
+}
+
+// checkCrossDomainPolicies validates X-Permitted-Cross-Domain-Policies
+func checkCrossDomainPolicies(policy string, timestamp time.Time) models.CheckResult {
+	if policy == "" {
+		return models.CheckResult{
+			Name:      "X-Permitted-Cross-Domain-Policies",
+			Status:    models.StatusWarning,
+			Message:   "Missing X-Permitted-Cross-Domain-Policies header",
+			Outcome:   "missing",
+			Details:   "Add X-Permitted-Cross-Domain-Policies: none to stop Flash and PDF clients loading cross-domain policy files",
+			Timestamp: timestamp,
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "none", "master-only", "none-this-response":
+		return models.CheckResult{
+			Name:      "X-Permitted-Cross-Domain-Policies",
+			Status:    models.StatusPass,
+			Message:   "X-Permitted-Cross-Domain-Policies properly configured",
+			Details:   fmt.Sprintf("Set to %s", policy),
+			Timestamp: timestamp,
+		}
+	case "by-content-type", "by-ftp-filename":
+		return models.CheckResult{
+			Name:      "X-Permitted-Cross-Domain-Policies",
+			Status:    models.StatusWarning,
+			Message:   "X-Permitted-Cross-Domain-Policies allows additional policy files",
+			Details:   fmt.Sprintf("Set to %s. Use none unless cross-domain policy files are required", policy),
+			Timestamp: timestamp,
+		}
+	case "all":
+		return models.CheckResult{
+			Name:      "X-Permitted-Cross-Domain-Policies",
+			Status:    models.StatusFail,
+			Message:   "X-Permitted-Cross-Domain-Policies allows all policy files",
+			Details:   "Set to all - any cross-domain policy file on the site is honoured. Use none",
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "X-Permitted-Cross-Domain-Policies",
+		Status:    models.StatusWarning,
+		Message:   "X-Permitted-Cross-Domain-Policies has invalid value",
+		Outcome:   "invalid",
+		Details:   fmt.Sprintf("Invalid value: %s. Use none", policy),
+		Timestamp: timestamp,
+	}
+}
+
+// checkClearSiteData validates the directives of a Clear-Site-Data header
+func checkClearSiteData(csd string, timestamp time.Time) models.CheckResult {
+	var directives, invalid []string
+	for _, directive := range strings.Split(csd, ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+		if validClearSiteDataDirectives[strings.ToLower(directive)] {
+			directives = append(directives, directive)
+		} else {
+			invalid = append(invalid, directive)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return models.CheckResult{
+			Name:      "Clear-Site-Data",
+			Status:    models.StatusWarning,
+			Message:   "Clear-Site-Data has invalid directives",
+			Outcome:   "invalid-directives",
+			Details:   fmt.Sprintf("Invalid: %s. Directives must be quoted, e.g. \"cache\", \"cookies\", \"storage\"", strings.Join(invalid, ", ")),
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Clear-Site-Data",
+		Status:    models.StatusWarning,
+		Message:   "Clear-Site-Data sent on a regular page",
+		Details:   fmt.Sprintf("Directives: %s. This header wipes client state and is normally only sent on logout responses", strings.Join(directives, ", ")),
+		Timestamp: timestamp,
+	}
+}
+
+// headerToken returns the first token of a header value, dropping parameters such as report-to
+func headerToken(value string) string {
+	if semi := strings.Index(value, ";"); semi != -1 {
+		value = value[:semi]
+	}
+	return strings.TrimSpace(value)
+}
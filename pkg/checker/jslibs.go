@@ -0,0 +1,410 @@
+package checker
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+//go:embed data/js_vulnerabilities.json
+var defaultVulnerabilityDB []byte
+
+// maxBannerScripts caps how many external scripts are fetched to read version banners
+const maxBannerScripts = 15
+
+// maxBannerBytes is how much of each script is read when looking for a banner comment
+const maxBannerBytes = 4 * 1024
+
+// bannerClient fetches external scripts to read their version banners
+var bannerClient = &http.Client{Timeout: 10 * time.Second}
+
+// VulnerabilityDB describes how to detect JavaScript libraries and which versions are vulnerable
+type VulnerabilityDB struct {
+	Updated   string          `json:"updated"`
+	Libraries []LibraryRecord `json:"libraries"`
+}
+
+// LibraryRecord holds the detection patterns and advisories for one library
+type LibraryRecord struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	URL             []string        `json:"url"`    // Patterns matched against script URLs; group 1 is the version
+	Banner          []string        `json:"banner"` // Patterns matched against script contents; group 1 is the version
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+
+	urlPatterns    []*regexp.Regexp
+	bannerPatterns []*regexp.Regexp
+}
+
+// Vulnerability is an advisory affecting versions in [AtOrAbove, Below)
+type Vulnerability struct {
+	AtOrAbove   string   `json:"atOrAbove,omitempty"`
+	Below       string   `json:"below,omitempty"`
+	Identifiers []string `json:"identifiers"`
+	Severity    string   `json:"severity"`
+	Summary     string   `json:"summary"`
+}
+
+// DetectedLibrary is a library version found on the page
+type DetectedLibrary struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"` // Script URL, or "inline script"
+}
+
+// DefaultVulnerabilityDB returns the vulnerability database embedded in the binary
+func DefaultVulnerabilityDB() *VulnerabilityDB {
+	db, err := parseVulnerabilityDB(defaultVulnerabilityDB)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded vulnerability database: %v", err))
+	}
+	return db
+}
+
+// LoadVulnerabilityDB reads a vulnerability database from a JSON file, replacing the embedded one
+func LoadVulnerabilityDB(filename string) (*VulnerabilityDB, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vulnerability database: %w", err)
+	}
+	return parseVulnerabilityDB(data)
+}
+
+// parseVulnerabilityDB decodes and compiles a vulnerability database
+func parseVulnerabilityDB(data []byte) (*VulnerabilityDB, error) {
+	var db VulnerabilityDB
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse vulnerability database: %w", err)
+	}
+
+	for i := range db.Libraries {
+		lib := &db.Libraries[i]
+		for _, pattern := range lib.URL {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URL pattern for %s: %w", lib.ID, err)
+			}
+			lib.urlPatterns = append(lib.urlPatterns, re)
+		}
+		for _, pattern := range lib.Banner {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid banner pattern for %s: %w", lib.ID, err)
+			}
+			lib.bannerPatterns = append(lib.bannerPatterns, re)
+		}
+	}
+
+	return &db, nil
+}
+
+// CheckJSLibraries detects JavaScript libraries loaded by the page and reports versions with known vulnerabilities
+func CheckJSLibraries(pageURL string, htmlContent string, db *VulnerabilityDB) []models.CheckResult {
+	start := time.Now()
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "JavaScript Libraries",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "JavaScript Libraries",
+			Status:    models.StatusFail,
+			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	if db == nil {
+		db = DefaultVulnerabilityDB()
+	}
+
+	detected := detectJSLibraries(doc, base, db)
+
+	var results []models.CheckResult
+	for _, lib := range detected {
+		record := db.library(lib.ID)
+		if record == nil {
+			continue
+		}
+
+		var advisories []Vulnerability
+		var ids []string
+		for _, vuln := range record.Vulnerabilities {
+			if vuln.affects(lib.Version) {
+				advisories = append(advisories, vuln)
+				ids = append(ids, vuln.Identifiers...)
+			}
+		}
+		if len(advisories) == 0 {
+			continue
+		}
+
+		results = append(results, models.CheckResult{
+			Name:    fmt.Sprintf("Vulnerable JavaScript Library (%s %s)", lib.Name, lib.Version),
+			Status:  models.StatusFail,
+			Message: fmt.Sprintf("%s %s has %d known vulnerabilities", lib.Name, lib.Version, len(advisories)),
+			Outcome: lib.ID,
+			Details: fmt.Sprintf("Loaded from %s. Advisories: %s. Upgrade to a patched release", lib.Source, strings.Join(ids, ", ")),
+			Evidence: map[string]any{
+				"library":    lib,
+				"advisories": advisories,
+				"db_updated": db.Updated,
+			},
+			Timestamp: start,
+		})
+	}
+
+	if len(results) > 0 {
+		return results
+	}
+
+	var names []string
+	for _, lib := range detected {
+		names = append(names, fmt.Sprintf("%s %s", lib.Name, lib.Version))
+	}
+
+	details := "No known libraries detected"
+	if len(names) > 0 {
+		details = "Detected: " + strings.Join(names, ", ")
+	}
+
+	return []models.CheckResult{{
+		Name:      "JavaScript Libraries",
+		Status:    models.StatusPass,
+		Message:   "No vulnerable JavaScript libraries detected",
+		Details:   details + fmt.Sprintf(" (vulnerability database updated %s)", db.Updated),
+		Evidence:  map[string]any{"libraries": detected, "db_updated": db.Updated},
+		Timestamp: start,
+	}}
+}
+
+// CheckJSLibrariesFromURL fetches HTML content from URL and checks its JavaScript libraries
+func CheckJSLibrariesFromURL(pageURL string, db *VulnerabilityDB) []models.CheckResult {
+	start := time.Now()
+
+	htmlContent, finalURL, err := fetchHTML(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "JavaScript Libraries",
+			Status:    models.StatusFail,
+			Message:   "Failed to fetch page",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	return CheckJSLibraries(finalURL, htmlContent, db)
+}
+
+// detectJSLibraries identifies libraries from script URLs, falling back to banners in inline and external scripts
+func detectJSLibraries(doc *html.Node, base *url.URL, db *VulnerabilityDB) []DetectedLibrary {
+	seen := make(map[string]bool)
+	var detected []DetectedLibrary
+	add := func(lib DetectedLibrary) {
+		key := lib.ID + "@" + lib.Version
+		if !seen[key] {
+			seen[key] = true
+			detected = append(detected, lib)
+		}
+	}
+
+	var unidentified []string
+	for _, res := range extractSubresources(doc, base) {
+		if res.Kind != "script" {
+			continue
+		}
+		if lib, ok := db.matchURL(res.URL); ok {
+			add(lib)
+		} else {
+			unidentified = append(unidentified, res.URL)
+		}
+	}
+
+	for _, script := range inlineScripts(doc) {
+		for _, lib := range db.matchBanner(script, "inline script") {
+			add(lib)
+		}
+	}
+
+	if len(unidentified) > maxBannerScripts {
+		unidentified = unidentified[:maxBannerScripts]
+	}
+	for _, src := range unidentified {
+		head, err := fetchScriptHead(src)
+		if err != nil {
+			continue
+		}
+		for _, lib := range db.matchBanner(head, src) {
+			add(lib)
+		}
+	}
+
+	sort.Slice(detected, func(i, j int) bool {
+		if detected[i].Name != detected[j].Name {
+			return detected[i].Name < detected[j].Name
+		}
+		return compareVersions(detected[i].Version, detected[j].Version) < 0
+	})
+
+	return detected
+}
+
+// matchURL identifies a library and version from a script URL
+func (db *VulnerabilityDB) matchURL(scriptURL string) (DetectedLibrary, bool) {
+	lower := strings.ToLower(scriptURL)
+	for _, lib := range db.Libraries {
+		for _, re := range lib.urlPatterns {
+			if m := re.FindStringSubmatch(lower); len(m) > 1 {
+				return DetectedLibrary{ID: lib.ID, Name: lib.Name, Version: m[1], Source: scriptURL}, true
+			}
+		}
+	}
+	return DetectedLibrary{}, false
+}
+
+// matchBanner identifies libraries from version banners inside script content
+func (db *VulnerabilityDB) matchBanner(content string, source string) []DetectedLibrary {
+	var found []DetectedLibrary
+	for _, lib := range db.Libraries {
+		for _, re := range lib.bannerPatterns {
+			if m := re.FindStringSubmatch(content); len(m) > 1 {
+				found = append(found, DetectedLibrary{ID: lib.ID, Name: lib.Name, Version: m[1], Source: source})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// library returns the record with the given ID
+func (db *VulnerabilityDB) library(id string) *LibraryRecord {
+	for i := range db.Libraries {
+		if db.Libraries[i].ID == id {
+			return &db.Libraries[i]
+		}
+	}
+	return nil
+}
+
+// affects reports whether version falls inside the advisory's range
+func (v Vulnerability) affects(version string) bool {
+	if v.AtOrAbove != "" && compareVersions(version, v.AtOrAbove) < 0 {
+		return false
+	}
+	if v.Below != "" && compareVersions(version, v.Below) >= 0 {
+		return false
+	}
+	return true
+}
+
+// compareVersions compares dotted numeric versions, ignoring pre-release suffixes
+func compareVersions(a string, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version into its numeric components
+func versionParts(version string) []int {
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		digits := field
+		for i, r := range field {
+			if r < '0' || r > '9' {
+				digits = field[:i]
+				break
+			}
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// inlineScripts returns the contents of script elements without a src attribute
+func inlineScripts(doc *html.Node) []string {
+	var scripts []string
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" {
+			hasSrc := false
+			for _, attr := range n.Attr {
+				if attr.Key == "src" {
+					hasSrc = true
+				}
+			}
+			if !hasSrc && n.FirstChild != nil {
+				scripts = append(scripts, n.FirstChild.Data)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+
+	traverse(doc)
+	return scripts
+}
+
+// fetchScriptHead reads the beginning of an external script, where banner comments live
+func fetchScriptHead(src string) (string, error) {
+	resp, err := bannerClient.Get(src)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("HTTP %d response", resp.StatusCode)
+	}
+
+	head, err := io.ReadAll(io.LimitReader(resp.Body, maxBannerBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(head), nil
+}
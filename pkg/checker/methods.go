@@ -0,0 +1,488 @@
+package checker
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// dangerousMethods are methods that should not be enabled on a public web page
+var dangerousMethods = []string{"PUT", "DELETE", "TRACE", "TRACK", "CONNECT", "PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"}
+
+// stackTracePatterns identify framework error pages that leak stack traces or internals
+var stackTracePatterns = map[string]*regexp.Regexp{
+	"Python traceback":    regexp.MustCompile(`Traceback \(most recent call last\)`),
+	"Java stack trace":    regexp.MustCompile(`(?m)^\s*at [a-zA-Z0-9_$.]+\([A-Za-z0-9_]+\.java:\d+\)`),
+	"Java exception":      regexp.MustCompile(`(java|javax|org\.springframework)\.[A-Za-z.]+Exception`),
+	".NET stack trace":    regexp.MustCompile(`(Server Error in '/' Application|System\.[A-Za-z.]+Exception|\[HttpException)`),
+	"PHP error":           regexp.MustCompile(`(Fatal error|Parse error|Warning|Notice)</b>:.* on line <b>\d+`),
+	"Node.js stack trace": regexp.MustCompile(`(?m)^\s*at .+ \(/.+\.js:\d+:\d+\)`),
+	"Ruby on Rails error": regexp.MustCompile(`(Action Controller: Exception caught|ActiveRecord::[A-Za-z]+)`),
+	"Django debug page":   regexp.MustCompile(`(You're seeing this error because you have <code>DEBUG = True</code>|Django Version:)`),
+	"Laravel debug page":  regexp.MustCompile(`(Whoops! There was an error|Illuminate\\[A-Za-z\\]+Exception)`),
+	"Go panic":            regexp.MustCompile(`goroutine \d+ \[running\]`),
+}
+
+// defaultPagePatterns identify out-of-the-box server welcome pages
+var defaultPagePatterns = map[string]*regexp.Regexp{
+	"nginx welcome page":           regexp.MustCompile(`<title>Welcome to nginx!</title>`),
+	"Apache default page":          regexp.MustCompile(`(Apache2 (Ubuntu|Debian) Default Page|<h1>It works!</h1>|Test Page for the Apache HTTP Server)`),
+	"IIS default page":             regexp.MustCompile(`<title>(IIS Windows Server|Internet Information Services|IIS\d? Welcome)</title>`),
+	"Tomcat default page":          regexp.MustCompile(`If you're seeing this, you've successfully installed Tomcat`),
+	"lighttpd placeholder page":    regexp.MustCompile(`lighttpd server is running|Placeholder page`),
+	"Caddy default page":           regexp.MustCompile(`Caddy works!`),
+	"Plesk/cPanel default page":    regexp.MustCompile(`(Default Parallels Plesk Panel Page|Default Web Site Page|cPanel, Inc\.)`),
+	"Microsoft Azure default page": regexp.MustCompile(`Your app service is up and running`),
+}
+
+// probeResponse is the subset of an HTTP response the method probes inspect
+type probeResponse struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+// CheckHTTPMethods probes the audited URL with OPTIONS, TRACE, PUT and DELETE and looks for verbose
+// error pages and default server pages, waiting delay between requests. PUT and DELETE only target a
+// randomly named resource next to the audited page so existing content is never modified.
+func CheckHTTPMethods(targetURL string, delay time.Duration) []models.CheckResult {
+	start := time.Now()
+
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "HTTP Methods",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	token, err := probeToken()
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "HTTP Methods",
+			Status:    models.StatusFail,
+			Message:   "Failed to generate probe token",
+			Outcome:   "probe-error",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	prober := &methodProber{delay: delay}
+	return []models.CheckResult{
+		checkAllowedMethods(prober, targetURL, start),
+		checkTraceMethod(prober, targetURL, token, start),
+		checkWriteMethods(prober, u, token, start),
+		checkVerboseErrors(prober, u, token, start),
+		checkDefaultServerPage(prober, u, start),
+	}
+}
+
+// methodProber sends the method probes, spacing requests out by delay
+type methodProber struct {
+	delay time.Duration
+	sent  bool
+}
+
+// send waits for the probe delay, then issues a request like sendMethodProbe
+func (p *methodProber) send(method string, target string, header http.Header, body string) (*probeResponse, error) {
+	p.wait()
+	return sendMethodProbe(method, target, header, body)
+}
+
+// sendRaw waits for the probe delay, then writes a raw request like sendRawRequest
+func (p *methodProber) sendRaw(u *url.URL, raw string) (*probeResponse, error) {
+	p.wait()
+	return sendRawRequest(u, raw)
+}
+
+// wait sleeps for the delay before every request but the first
+func (p *methodProber) wait() {
+	if p.sent && p.delay > 0 {
+		time.Sleep(p.delay)
+	}
+	p.sent = true
+}
+
+// checkAllowedMethods inspects the methods advertised in response to OPTIONS
+func checkAllowedMethods(prober *methodProber, targetURL string, timestamp time.Time) models.CheckResult {
+	resp, err := prober.send(http.MethodOptions, targetURL, nil, "")
+	if err != nil {
+		return models.CheckResult{
+			Name:      "HTTP Methods",
+			Status:    models.StatusWarning,
+			Message:   "OPTIONS request failed",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	allow := resp.Header.Get("Allow")
+	if public := resp.Header.Get("Public"); public != "" {
+		allow = strings.Trim(allow+", "+public, ", ")
+	}
+
+	var advertised, dangerous []string
+	for _, method := range strings.Split(allow, ",") {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" || containsString(advertised, method) {
+			continue
+		}
+		advertised = append(advertised, method)
+		if containsString(dangerousMethods, method) {
+			dangerous = append(dangerous, method)
+		}
+	}
+
+	evidence := map[string]any{
+		"status":     resp.Status,
+		"advertised": advertised,
+		"dangerous":  dangerous,
+	}
+
+	if len(dangerous) > 0 {
+		return models.CheckResult{
+			Name:      "HTTP Methods",
+			Status:    models.StatusWarning,
+			Message:   "Dangerous HTTP methods advertised",
+			Details:   fmt.Sprintf("OPTIONS advertises %s. Disable methods the site doesn't need", strings.Join(dangerous, ", ")),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	details := "No Allow header returned"
+	if len(advertised) > 0 {
+		details = fmt.Sprintf("Allowed methods: %s", strings.Join(advertised, ", "))
+	}
+
+	return models.CheckResult{
+		Name:      "HTTP Methods",
+		Status:    models.StatusPass,
+		Message:   "No dangerous HTTP methods advertised",
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkTraceMethod sends TRACE with a marker header and flags cross-site tracing if it is echoed back
+func checkTraceMethod(prober *methodProber, targetURL string, token string, timestamp time.Time) models.CheckResult {
+	marker := "checkly-" + token
+	resp, err := prober.send("TRACE", targetURL, http.Header{"X-Checkly-Trace": {marker}}, "")
+	if err != nil {
+		return models.CheckResult{
+			Name:      "TRACE Method (XST)",
+			Status:    models.StatusPass,
+			Message:   "TRACE request refused",
+			Details:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+
+	evidence := map[string]any{"status": resp.Status}
+
+	if resp.Status == http.StatusOK && strings.Contains(resp.Body, marker) {
+		return models.CheckResult{
+			Name:      "TRACE Method (XST)",
+			Status:    models.StatusFail,
+			Message:   "TRACE reflects request headers",
+			Details:   "TRACE echoes the request back, enabling cross-site tracing (XST). Disable TRACE on the web server",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if resp.Status >= 200 && resp.Status < 300 {
+		return models.CheckResult{
+			Name:      "TRACE Method (XST)",
+			Status:    models.StatusWarning,
+			Message:   "TRACE method enabled",
+			Details:   fmt.Sprintf("TRACE returned HTTP %d without reflecting headers. Disable TRACE on the web server", resp.Status),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "TRACE Method (XST)",
+		Status:    models.StatusPass,
+		Message:   "TRACE method disabled",
+		Details:   fmt.Sprintf("TRACE returned HTTP %d", resp.Status),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkWriteMethods tries PUT and DELETE against a throwaway resource next to the audited page
+func checkWriteMethods(prober *methodProber, u *url.URL, token string, timestamp time.Time) models.CheckResult {
+	dir := u.Path
+	if !strings.HasSuffix(dir, "/") {
+		dir = dir[:strings.LastIndex(dir, "/")+1]
+	}
+	if dir == "" {
+		dir = "/"
+	}
+	probe := *u
+	probe.Path = dir + "checkly-probe-" + token + ".txt"
+	probe.RawQuery = ""
+	target := probe.String()
+
+	// Many servers answer every method with a success status, so only an effect confirmed with a
+	// follow-up GET counts as a failure; unconfirmed success statuses are reported as warnings
+	var confirmed, unconfirmed []string
+	evidence := map[string]any{"resource": target}
+
+	// The token is also in the probe path, so only the full payload proves the upload: error pages
+	// often echo the requested path
+	payload := "checkly method probe " + token
+	uploaded := false
+	put, err := prober.send(http.MethodPut, target, http.Header{"Content-Type": {"text/plain"}}, payload)
+	if err == nil {
+		evidence["put_status"] = put.Status
+		if put.Status == http.StatusOK || put.Status == http.StatusCreated || put.Status == http.StatusNoContent {
+			if get, err := prober.send(http.MethodGet, target, nil, ""); err == nil && get.Status == http.StatusOK && strings.Contains(get.Body, payload) {
+				uploaded = true
+				confirmed = append(confirmed, "PUT (file upload confirmed)")
+			} else {
+				unconfirmed = append(unconfirmed, fmt.Sprintf("PUT returned HTTP %d", put.Status))
+			}
+		}
+	}
+
+	// DELETE also cleans up after a successful PUT; it can only be confirmed on an uploaded file that
+	// is gone afterwards
+	del, err := prober.send(http.MethodDelete, target, nil, "")
+	if err == nil {
+		evidence["delete_status"] = del.Status
+		if del.Status == http.StatusOK || del.Status == http.StatusAccepted || del.Status == http.StatusNoContent {
+			get, err := prober.send(http.MethodGet, target, nil, "")
+			if uploaded && err == nil && (get.Status == http.StatusNotFound || get.Status == http.StatusGone) {
+				confirmed = append(confirmed, "DELETE (file removal confirmed)")
+			} else {
+				unconfirmed = append(unconfirmed, fmt.Sprintf("DELETE returned HTTP %d", del.Status))
+			}
+		}
+	}
+
+	if len(confirmed) > 0 {
+		return models.CheckResult{
+			Name:      "PUT/DELETE Methods",
+			Status:    models.StatusFail,
+			Message:   "Write methods accepted by the server",
+			Details:   fmt.Sprintf("%s for %s. Disable write methods or require authentication", strings.Join(append(confirmed, unconfirmed...), ", "), target),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(unconfirmed) > 0 {
+		return models.CheckResult{
+			Name:      "PUT/DELETE Methods",
+			Status:    models.StatusWarning,
+			Message:   "Write methods answered with success but had no confirmed effect",
+			Details:   fmt.Sprintf("%s for %s, but a follow-up GET didn't confirm the change. The server may answer every method with success; check that write methods are disabled", strings.Join(unconfirmed, ", "), target),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "PUT/DELETE Methods",
+		Status:    models.StatusPass,
+		Message:   "PUT and DELETE rejected",
+		Details:   fmt.Sprintf("Write methods were not accepted for %s", target),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkVerboseErrors sends malformed requests and inspects the error pages for stack traces
+func checkVerboseErrors(prober *methodProber, u *url.URL, token string, timestamp time.Time) models.CheckResult {
+	requests := []string{
+		fmt.Sprintf("GET /%%%s%%ZZ HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", token, u.Host),
+		fmt.Sprintf("GET %s?checkly[%s]=%%27%%22%%3C HTTP/1.1\r\nHost: %s\r\nContent-Length: invalid\r\nConnection: close\r\n\r\n", requestPath(u), token, u.Host),
+	}
+
+	var leaks, statuses []string
+	for _, raw := range requests {
+		resp, err := prober.sendRaw(u, raw)
+		if err != nil {
+			continue
+		}
+		statuses = append(statuses, fmt.Sprintf("HTTP %d", resp.Status))
+		for name, pattern := range stackTracePatterns {
+			if pattern.MatchString(resp.Body) && !containsString(leaks, name) {
+				leaks = append(leaks, name)
+			}
+		}
+	}
+
+	evidence := map[string]any{
+		"responses": statuses,
+		"leaks":     leaks,
+	}
+
+	if len(leaks) > 0 {
+		return models.CheckResult{
+			Name:      "Verbose Error Pages",
+			Status:    models.StatusFail,
+			Message:   "Error pages leak stack traces",
+			Details:   fmt.Sprintf("Malformed requests returned %s. Disable debug mode and use generic error pages", strings.Join(leaks, ", ")),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(statuses) == 0 {
+		return models.CheckResult{
+			Name:      "Verbose Error Pages",
+			Status:    models.StatusWarning,
+			Message:   "Malformed requests could not be sent",
+			Details:   "The server closed the connection before responding",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Verbose Error Pages",
+		Status:    models.StatusPass,
+		Message:   "Error pages don't leak internals",
+		Details:   fmt.Sprintf("Malformed requests returned %s without stack traces", strings.Join(statuses, ", ")),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkDefaultServerPage looks for out-of-the-box welcome pages on the audited URL and site root
+func checkDefaultServerPage(prober *methodProber, u *url.URL, timestamp time.Time) models.CheckResult {
+	targets := []string{u.String()}
+	if root := fmt.Sprintf("%s://%s/", u.Scheme, u.Host); root != u.String() {
+		targets = append(targets, root)
+	}
+
+	for _, target := range targets {
+		resp, err := prober.send(http.MethodGet, target, nil, "")
+		if err != nil {
+			continue
+		}
+		for name, pattern := range defaultPagePatterns {
+			if pattern.MatchString(resp.Body) {
+				return models.CheckResult{
+					Name:      "Default Server Page",
+					Status:    models.StatusFail,
+					Message:   fmt.Sprintf("%s detected", name),
+					Details:   fmt.Sprintf("%s serves a default installation page, revealing the server software and an unconfigured host", target),
+					Evidence:  map[string]any{"url": target, "page": name},
+					Timestamp: timestamp,
+				}
+			}
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Default Server Page",
+		Status:    models.StatusPass,
+		Message:   "No default server pages found",
+		Details:   fmt.Sprintf("Checked %s", strings.Join(targets, ", ")),
+		Timestamp: timestamp,
+	}
+}
+
+// sendMethodProbe issues a request with an arbitrary method without following redirects
+func sendMethodProbe(method string, target string, header http.Header, body string) (*probeResponse, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxExposureBody))
+	return &probeResponse{Status: resp.StatusCode, Header: resp.Header, Body: string(data)}, nil
+}
+
+// sendRawRequest writes a hand-built request to the server, bypassing net/http's request validation
+func sendRawRequest(u *url.URL, raw string) (*probeResponse, error) {
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if u.Scheme == "https" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(15 * time.Second))
+	if _, err := io.WriteString(conn, raw); err != nil {
+		return nil, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxExposureBody))
+	return &probeResponse{Status: resp.StatusCode, Header: resp.Header, Body: string(data)}, nil
+}
+
+// requestPath returns the escaped path of u, defaulting to /
+func requestPath(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
+
+// probeToken returns a random hex token used to name probe resources
+func probeToken() (string, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
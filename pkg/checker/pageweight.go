@@ -0,0 +1,420 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// maxWeighedResources caps how many subresources are downloaded to measure page weight
+const maxWeighedResources = 150
+
+// weightConcurrency is how many subresources are downloaded at once
+const weightConcurrency = 8
+
+// Budget is a pair of limits: above Warning the result is a warning, above Fail it fails
+type Budget struct {
+	Warning int64 `json:"warning"`
+	Fail    int64 `json:"fail"`
+}
+
+// PageBudgets holds the limits page weight is compared against; byte budgets count transferred bytes
+type PageBudgets struct {
+	TotalBytes      Budget `json:"total_bytes"`
+	Requests        Budget `json:"requests"`
+	ScriptBytes     Budget `json:"script_bytes"`
+	StylesheetBytes Budget `json:"stylesheet_bytes"`
+	ImageBytes      Budget `json:"image_bytes"`
+	FontBytes       Budget `json:"font_bytes"`
+	RenderBlocking  Budget `json:"render_blocking"`
+}
+
+// ResourceWeight is the measured size of one subresource
+type ResourceWeight struct {
+	Kind  string `json:"kind"`
+	URL   string `json:"url"`
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+// DefaultPageBudgets returns budgets based on the median mobile page weight and common performance guidance
+func DefaultPageBudgets() *PageBudgets {
+	return &PageBudgets{
+		TotalBytes:      Budget{Warning: 1600 * 1024, Fail: 3 * 1024 * 1024},
+		Requests:        Budget{Warning: 75, Fail: 150},
+		ScriptBytes:     Budget{Warning: 350 * 1024, Fail: 1024 * 1024},
+		StylesheetBytes: Budget{Warning: 100 * 1024, Fail: 300 * 1024},
+		ImageBytes:      Budget{Warning: 1024 * 1024, Fail: 2560 * 1024},
+		FontBytes:       Budget{Warning: 150 * 1024, Fail: 400 * 1024},
+		RenderBlocking:  Budget{Warning: 2, Fail: 5},
+	}
+}
+
+// LoadPageBudgets reads budgets from a JSON file; limits missing from the file keep their defaults
+func LoadPageBudgets(filename string) (*PageBudgets, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page budgets: %w", err)
+	}
+
+	budgets := DefaultPageBudgets()
+	if err := json.Unmarshal(data, budgets); err != nil {
+		return nil, fmt.Errorf("failed to parse page budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// status grades value against the budget
+func (b Budget) status(value int64) models.Status {
+	switch {
+	case b.Fail > 0 && value > b.Fail:
+		return models.StatusFail
+	case b.Warning > 0 && value > b.Warning:
+		return models.StatusWarning
+	default:
+		return models.StatusPass
+	}
+}
+
+// CheckPageWeight downloads every subresource referenced by the HTML and compares total weight,
+// request count, per-type weight and render-blocking resources against budgets (nil uses the defaults)
+func CheckPageWeight(pageURL string, htmlContent string, budgets *PageBudgets) []models.CheckResult {
+	start := time.Now()
+
+	if budgets == nil {
+		budgets = DefaultPageBudgets()
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Page Weight",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Page Weight",
+			Status:    models.StatusFail,
+			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	// Render-blocking is judged on every reference; weight counts each URL once
+	referenced := extractSubresources(doc, base)
+	var resources []Subresource
+	seen := make(map[string]bool)
+	for _, res := range referenced {
+		if !seen[res.URL] {
+			seen[res.URL] = true
+			resources = append(resources, res)
+		}
+	}
+
+	weighed := resources
+	if len(weighed) > maxWeighedResources {
+		weighed = weighed[:maxWeighedResources]
+	}
+	weights := weighResources(weighed)
+
+	// Budgets count bytes on the wire, so the document is weighed compressed like its subresources;
+	// the decompressed size is the fallback when it can't be fetched again
+	documentBytes, err := transferSize(pageURL)
+	if err != nil {
+		documentBytes = int64(len(htmlContent))
+	}
+
+	return []models.CheckResult{
+		checkTotalWeight(documentBytes, weights, len(resources), budgets, start),
+		checkRequestCount(len(resources), budgets, start),
+		checkTypeBudgets(weights, budgets, start),
+		checkRenderBlocking(referenced, budgets, start),
+	}
+}
+
+// CheckPageWeightFromURL fetches HTML content from URL and checks its page weight
+func CheckPageWeightFromURL(pageURL string, budgets *PageBudgets) []models.CheckResult {
+	start := time.Now()
+
+	htmlContent, finalURL, err := fetchHTML(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Page Weight",
+			Status:    models.StatusFail,
+			Message:   "Failed to fetch page",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	return CheckPageWeight(finalURL, htmlContent, budgets)
+}
+
+// weighResources downloads resources concurrently and records their transferred size
+func weighResources(resources []Subresource) []ResourceWeight {
+	weights := make([]ResourceWeight, len(resources))
+	sem := make(chan struct{}, weightConcurrency)
+	var wg sync.WaitGroup
+
+	for i, res := range resources {
+		wg.Add(1)
+		go func(i int, res Subresource) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			weights[i] = ResourceWeight{Kind: res.Kind, URL: res.URL}
+			size, err := transferSize(res.URL)
+			if err != nil {
+				weights[i].Error = err.Error()
+				return
+			}
+			weights[i].Bytes = size
+		}(i, res)
+	}
+
+	wg.Wait()
+	return weights
+}
+
+// transferSize downloads a resource the way a browser would, without decompressing, and counts the bytes
+func transferSize(resourceURL string) (int64, error) {
+	req, err := http.NewRequest(http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept-Encoding", "br, gzip")
+
+	resp, err := rawClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("HTTP %d response", resp.StatusCode)
+	}
+
+	return io.Copy(io.Discard, resp.Body)
+}
+
+// checkTotalWeight reports the document plus subresource bytes and the largest resources
+func checkTotalWeight(documentBytes int64, weights []ResourceWeight, referenced int, budgets *PageBudgets, timestamp time.Time) models.CheckResult {
+	total := documentBytes
+	var failed int
+	for _, w := range weights {
+		total += w.Bytes
+		if w.Error != "" {
+			failed++
+		}
+	}
+
+	largest := make([]ResourceWeight, 0, len(weights))
+	for _, w := range weights {
+		if w.Error == "" {
+			largest = append(largest, w)
+		}
+	}
+	sort.Slice(largest, func(i, j int) bool { return largest[i].Bytes > largest[j].Bytes })
+	if len(largest) > 10 {
+		largest = largest[:10]
+	}
+
+	var top []string
+	for i, w := range largest {
+		if i == 3 {
+			break
+		}
+		top = append(top, fmt.Sprintf("%s (%s)", w.URL, formatBytes(w.Bytes)))
+	}
+
+	details := fmt.Sprintf("%s across %d requests (document %s)", formatBytes(total), len(weights)+1, formatBytes(documentBytes))
+	if len(top) > 0 {
+		details += ". Largest: " + strings.Join(top, ", ")
+	}
+	if failed > 0 {
+		details += fmt.Sprintf(". %d resources could not be fetched", failed)
+	}
+	if referenced > len(weights) {
+		details += fmt.Sprintf(". Only the first %d of %d resources were measured", len(weights), referenced)
+	}
+
+	status := budgets.TotalBytes.status(total)
+	message := "Page weight within budget"
+	switch status {
+	case models.StatusWarning:
+		message = fmt.Sprintf("Page weight exceeds the %s budget", formatBytes(budgets.TotalBytes.Warning))
+	case models.StatusFail:
+		message = fmt.Sprintf("Page weight far exceeds budget (limit %s)", formatBytes(budgets.TotalBytes.Fail))
+	}
+
+	return models.CheckResult{
+		Name:    "Page Weight",
+		Status:  status,
+		Message: message,
+		Details: details,
+		Evidence: map[string]any{
+			"total_bytes":    total,
+			"document_bytes": documentBytes,
+			"largest":        largest,
+			"budget":         budgets.TotalBytes,
+		},
+		Timestamp: timestamp,
+	}
+}
+
+// checkRequestCount compares the number of requests needed to load the page against its budget
+func checkRequestCount(referenced int, budgets *PageBudgets, timestamp time.Time) models.CheckResult {
+	requests := int64(referenced + 1)
+	status := budgets.Requests.status(requests)
+
+	message := "Request count within budget"
+	if status != models.StatusPass {
+		message = "Too many requests"
+	}
+
+	return models.CheckResult{
+		Name:      "Request Count",
+		Status:    status,
+		Message:   message,
+		Details:   fmt.Sprintf("%d requests (budget: warning above %d, fail above %d)", requests, budgets.Requests.Warning, budgets.Requests.Fail),
+		Evidence:  map[string]any{"requests": requests, "budget": budgets.Requests},
+		Timestamp: timestamp,
+	}
+}
+
+// checkTypeBudgets compares script, stylesheet, image and font bytes against their budgets
+func checkTypeBudgets(weights []ResourceWeight, budgets *PageBudgets, timestamp time.Time) models.CheckResult {
+	byKind := make(map[string]int64)
+	for _, w := range weights {
+		byKind[w.Kind] += w.Bytes
+	}
+
+	kinds := []struct {
+		kind   string
+		label  string
+		budget Budget
+	}{
+		{"script", "JavaScript", budgets.ScriptBytes},
+		{"stylesheet", "CSS", budgets.StylesheetBytes},
+		{"image", "Images", budgets.ImageBytes},
+		{"font", "Fonts", budgets.FontBytes},
+	}
+
+	status := models.StatusPass
+	var summary, over []string
+	for _, k := range kinds {
+		bytes := byKind[k.kind]
+		summary = append(summary, fmt.Sprintf("%s %s", k.label, formatBytes(bytes)))
+		switch k.budget.status(bytes) {
+		case models.StatusFail:
+			status = models.StatusFail
+			over = append(over, fmt.Sprintf("%s over %s", k.label, formatBytes(k.budget.Fail)))
+		case models.StatusWarning:
+			if status == models.StatusPass {
+				status = models.StatusWarning
+			}
+			over = append(over, fmt.Sprintf("%s over %s", k.label, formatBytes(k.budget.Warning)))
+		}
+	}
+
+	details := strings.Join(summary, ", ")
+	message := "Resource types within budget"
+	if len(over) > 0 {
+		message = "Resource type budgets exceeded"
+		details += ". Over budget: " + strings.Join(over, ", ")
+	}
+
+	return models.CheckResult{
+		Name:      "Resource Budgets",
+		Status:    status,
+		Message:   message,
+		Details:   details,
+		Evidence:  map[string]any{"bytes_by_type": byKind, "budgets": budgets},
+		Timestamp: timestamp,
+	}
+}
+
+// checkRenderBlocking counts head scripts without async/defer and stylesheets that apply to screens
+func checkRenderBlocking(resources []Subresource, budgets *PageBudgets, timestamp time.Time) models.CheckResult {
+	var blocking []string
+	seen := make(map[string]bool)
+	for _, res := range resources {
+		if !res.InHead || res.Async || seen[res.URL] {
+			continue
+		}
+		switch res.Kind {
+		case "script":
+			if !res.Defer && !res.Module {
+				seen[res.URL] = true
+				blocking = append(blocking, res.URL)
+			}
+		case "stylesheet":
+			media := strings.ToLower(strings.TrimSpace(res.Media))
+			if media == "" || media == "all" || strings.Contains(media, "screen") {
+				seen[res.URL] = true
+				blocking = append(blocking, res.URL)
+			}
+		}
+	}
+
+	status := budgets.RenderBlocking.status(int64(len(blocking)))
+	evidence := map[string]any{"render_blocking": blocking, "budget": budgets.RenderBlocking}
+
+	if len(blocking) == 0 {
+		return models.CheckResult{
+			Name:      "Render-Blocking Resources",
+			Status:    status,
+			Message:   "No render-blocking resources",
+			Details:   "Scripts in <head> are async or deferred",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	message := "Few render-blocking resources"
+	if status != models.StatusPass {
+		message = "Too many render-blocking resources"
+	}
+
+	return models.CheckResult{
+		Name:      "Render-Blocking Resources",
+		Status:    status,
+		Message:   message,
+		Details:   fmt.Sprintf("%d resources in <head> block first paint: %s. Defer scripts and inline critical CSS", len(blocking), strings.Join(blocking, ", ")),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// formatBytes renders a byte count in KB or MB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.0f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
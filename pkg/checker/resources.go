@@ -0,0 +1,184 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Subresource describes a resource referenced by a page's HTML
+type Subresource struct {
+	Kind           string // script, stylesheet, image, font, iframe, media
+	URL            string
+	Integrity      string
+	CrossOrigin    string
+	HasCrossOrigin bool // The crossorigin attribute is present; a bare attribute means anonymous
+	InHead         bool
+	Async          bool
+	Defer          bool
+	Module         bool
+	Media          string
+}
+
+// extractSubresources walks the parsed HTML and returns every external resource it references,
+// with URLs resolved against pageURL
+func extractSubresources(doc *html.Node, pageURL *url.URL) []Subresource {
+	var resources []Subresource
+
+	var traverse func(*html.Node, bool)
+	traverse = func(n *html.Node, inHead bool) {
+		if n.Type == html.ElementNode {
+			if n.Data == "head" {
+				inHead = true
+			}
+			if res, ok := subresourceFromNode(n, pageURL); ok {
+				res.InHead = inHead
+				resources = append(resources, res)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c, inHead)
+		}
+	}
+
+	traverse(doc, false)
+	return resources
+}
+
+// subresourceFromNode converts a single element into a Subresource when it references one
+func subresourceFromNode(n *html.Node, pageURL *url.URL) (Subresource, bool) {
+	attrs := make(map[string]string)
+	for _, attr := range n.Attr {
+		attrs[strings.ToLower(attr.Key)] = attr.Val
+	}
+	_, hasAsync := attrs["async"]
+	_, hasDefer := attrs["defer"]
+	_, hasCrossOrigin := attrs["crossorigin"]
+
+	res := Subresource{
+		Integrity:      strings.TrimSpace(attrs["integrity"]),
+		CrossOrigin:    attrs["crossorigin"],
+		HasCrossOrigin: hasCrossOrigin,
+		Media:          attrs["media"],
+	}
+
+	var ref string
+	switch n.Data {
+	case "script":
+		ref = attrs["src"]
+		res.Kind = "script"
+		res.Async = hasAsync
+		res.Defer = hasDefer
+		res.Module = strings.EqualFold(attrs["type"], "module")
+	case "link":
+		ref = attrs["href"]
+		rels := strings.Fields(strings.ToLower(attrs["rel"]))
+		switch {
+		case containsString(rels, "stylesheet"):
+			res.Kind = "stylesheet"
+		case containsString(rels, "preload") || containsString(rels, "modulepreload"):
+			switch strings.ToLower(attrs["as"]) {
+			case "script":
+				res.Kind = "script"
+			case "style":
+				res.Kind = "stylesheet"
+			case "font":
+				res.Kind = "font"
+			case "image":
+				res.Kind = "image"
+			default:
+				if containsString(rels, "modulepreload") {
+					res.Kind = "script"
+				}
+			}
+			// Preloads never block rendering on their own
+			res.Async = true
+		case containsString(rels, "icon"):
+			res.Kind = "image"
+		}
+	case "img", "input":
+		if n.Data == "input" && !strings.EqualFold(attrs["type"], "image") {
+			return res, false
+		}
+		ref = attrs["src"]
+		res.Kind = "image"
+	case "source":
+		ref = attrs["src"]
+		res.Kind = "media"
+		if ref == "" && attrs["srcset"] != "" {
+			ref = firstSrcsetURL(attrs["srcset"])
+			res.Kind = "image"
+		}
+	case "video", "audio":
+		ref = attrs["src"]
+		res.Kind = "media"
+	case "iframe":
+		ref = attrs["src"]
+		res.Kind = "iframe"
+	}
+
+	if res.Kind == "" || ref == "" {
+		return res, false
+	}
+
+	resolved, ok := resolveReference(pageURL, ref)
+	if !ok {
+		return res, false
+	}
+	res.URL = resolved.String()
+	return res, true
+}
+
+// resolveReference resolves ref against base, rejecting non-HTTP(S) references such as data: URIs
+func resolveReference(base *url.URL, ref string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, false
+	}
+	return u, true
+}
+
+// firstSrcsetURL returns the first candidate URL of a srcset attribute
+func firstSrcsetURL(srcset string) string {
+	candidate := strings.TrimSpace(strings.Split(srcset, ",")[0])
+	if fields := strings.Fields(candidate); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// isCrossOrigin reports whether resourceURL is served from a different origin than pageURL
+func isCrossOrigin(pageURL *url.URL, resourceURL string) bool {
+	u, err := url.Parse(resourceURL)
+	if err != nil {
+		return false
+	}
+	return !strings.EqualFold(u.Scheme, pageURL.Scheme) || !strings.EqualFold(u.Host, pageURL.Host)
+}
+
+// registrableDomain returns the eTLD+1 for host, falling back to the host itself
+func registrableDomain(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ func CheckRobotsTxt(baseURL string) models.CheckResult {
 			Name:      "Robots.txt",
 			Status:    models.StatusFail,
 			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
 			Details:   err.Error(),
 			Timestamp: start,
 		}
@@ -34,6 +35,7 @@ func CheckRobotsTxt(baseURL string) models.CheckResult {
 			Name:      "Robots.txt",
 			Status:    models.StatusFail,
 			Message:   "Failed to fetch robots.txt",
+			Outcome:   "unreachable",
 			Details:   err.Error(),
 			Timestamp: start,
 		}
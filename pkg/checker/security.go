@@ -19,12 +19,18 @@ type SecurityHeaders struct {
 	ReferrerPolicy          string
 	XSSProtection           string
 	PermissionsPolicy       string
+	CrossOriginOpener       string
+	CrossOriginEmbedder     string
+	CrossOriginResource     string
+	CrossDomainPolicies     string
+	ClearSiteData           string
 	Server                  string
 	XPoweredBy              string
 }
 
-// CheckSecurityHeaders checks URL for security headers and returns multiple results
-func CheckSecurityHeaders(url string) []models.CheckResult {
+// CheckSecurityHeaders checks URL for security headers and returns multiple results. The rules
+// fingerprint the technologies named by disclosing headers; nil uses DefaultTechnologyRules.
+func CheckSecurityHeaders(url string, rules []TechnologyRule) []models.CheckResult {
 	start := time.Now()
 	var results []models.CheckResult
 
@@ -35,6 +41,7 @@ func CheckSecurityHeaders(url string) []models.CheckResult {
 			Name:      "Security Headers",
 			Status:    models.StatusFail,
 			Message:   "Failed to fetch headers",
+			Outcome:   "unreachable",
 			Details:   err.Error(),
 			Timestamp: start,
 		}}
@@ -46,6 +53,7 @@ func CheckSecurityHeaders(url string) []models.CheckResult {
 			Name:      "Security Headers",
 			Status:    models.StatusFail,
 			Message:   fmt.Sprintf("HTTP %d response", resp.StatusCode),
+			Outcome:   "http-error",
 			Details:   fmt.Sprintf("Unable to check headers for %s", url),
 			Timestamp: start,
 		}}
@@ -61,7 +69,14 @@ func CheckSecurityHeaders(url string) []models.CheckResult {
 	results = append(results, checkXContentTypeOptions(headers.XContentTypeOptions, start))
 	results = append(results, checkReferrerPolicy(headers.ReferrerPolicy, start))
 	results = append(results, checkXSSProtection(headers.XSSProtection, start))
-	results = append(results, checkInformationDisclosure(headers, start))
+	results = append(results, checkPermissionsPolicy(headers.PermissionsPolicy, start))
+	results = append(results, checkCrossOriginIsolation(headers.CrossOriginOpener, headers.CrossOriginEmbedder, start))
+	results = append(results, checkCrossOriginResourcePolicy(headers.CrossOriginResource, start))
+	results = append(results, checkCrossDomainPolicies(headers.CrossDomainPolicies, start))
+	if headers.ClearSiteData != "" {
+		results = append(results, checkClearSiteData(headers.ClearSiteData, start))
+	}
+	results = append(results, checkInformationDisclosure(headers, resp.Header, DetectTechnologies(rules, url, resp.Header, ""), start))
 
 	return results
 }
@@ -76,6 +91,11 @@ func extractSecurityHeaders(httpHeaders http.Header) SecurityHeaders {
 		ReferrerPolicy:          httpHeaders.Get("Referrer-Policy"),
 		XSSProtection:           httpHeaders.Get("X-XSS-Protection"),
 		PermissionsPolicy:       httpHeaders.Get("Permissions-Policy"),
+		CrossOriginOpener:       httpHeaders.Get("Cross-Origin-Opener-Policy"),
+		CrossOriginEmbedder:     httpHeaders.Get("Cross-Origin-Embedder-Policy"),
+		CrossOriginResource:     httpHeaders.Get("Cross-Origin-Resource-Policy"),
+		CrossDomainPolicies:     httpHeaders.Get("X-Permitted-Cross-Domain-Policies"),
+		ClearSiteData:           httpHeaders.Get("Clear-Site-Data"),
 		Server:                  httpHeaders.Get("Server"),
 		XPoweredBy:              httpHeaders.Get("X-Powered-By"),
 	}
@@ -93,24 +113,7 @@ func checkStrictTransportSecurity(hsts string, timestamp time.Time) models.Check
 		}
 	}
 
-	// Parse max-age value
-	var maxAge int
-	var includeSubDomains bool
-	var preload bool
-
-	parts := strings.Split(hsts, ";")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if strings.HasPrefix(part, "max-age=") {
-			if age, err := strconv.Atoi(part[8:]); err == nil {
-				maxAge = age
-			}
-		} else if part == "includeSubDomains" {
-			includeSubDomains = true
-		} else if part == "preload" {
-			preload = true
-		}
-	}
+	maxAge, includeSubDomains, preload := parseHSTS(hsts)
 
 	var warnings []string
 	if maxAge < 31536000 { // 1 year
@@ -149,6 +152,25 @@ func checkStrictTransportSecurity(hsts string, timestamp time.Time) models.Check
 	}
 }
 
+// parseHSTS extracts the max-age, includeSubDomains and preload directives from an HSTS header.
+// Directive names are case-insensitive and max-age may be quoted (RFC 6797 section 6.1).
+func parseHSTS(hsts string) (maxAge int, includeSubDomains bool, preload bool) {
+	for _, part := range strings.Split(hsts, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if age, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`)); err == nil {
+				maxAge = age
+			}
+		case "includesubdomains":
+			includeSubDomains = true
+		case "preload":
+			preload = true
+		}
+	}
+	return maxAge, includeSubDomains, preload
+}
+
 // checkContentSecurityPolicy validates CSP header
 func checkContentSecurityPolicy(csp string, timestamp time.Time) models.CheckResult {
 	if csp == "" {
@@ -245,6 +267,7 @@ func checkXFrameOptions(xfo string, timestamp time.Time) models.CheckResult {
 				Name:      "X-Frame-Options",
 				Status:    models.StatusWarning,
 				Message:   "X-Frame-Options uses deprecated ALLOW-FROM",
+				Outcome:   "allow-from",
 				Details:   "ALLOW-FROM is deprecated, consider using CSP frame-ancestors instead",
 				Timestamp: timestamp,
 			}
@@ -299,6 +322,7 @@ func checkReferrerPolicy(rp string, timestamp time.Time) models.CheckResult {
 			Name:      "Referrer Policy",
 			Status:    models.StatusWarning,
 			Message:   "Missing Referrer-Policy header",
+			Outcome:   "missing",
 			Details:   "Consider adding Referrer-Policy header to control referrer information",
 			Timestamp: timestamp,
 		}
@@ -344,6 +368,7 @@ func checkXSSProtection(xss string, timestamp time.Time) models.CheckResult {
 			Name:      "X-XSS-Protection",
 			Status:    models.StatusWarning,
 			Message:   "Missing X-XSS-Protection header",
+			Outcome:   "missing",
 			Details:   "Consider adding X-XSS-Protection header (though CSP is preferred)",
 			Timestamp: timestamp,
 		}
@@ -366,6 +391,7 @@ func checkXSSProtection(xss string, timestamp time.Time) models.CheckResult {
 			Name:      "X-XSS-Protection",
 			Status:    models.StatusWarning,
 			Message:   "X-XSS-Protection disabled",
+			Outcome:   "disabled",
 			Details:   "XSS protection is disabled. Ensure strong CSP is in place",
 			Timestamp: timestamp,
 		}
@@ -380,27 +406,58 @@ func checkXSSProtection(xss string, timestamp time.Time) models.CheckResult {
 	}
 }
 
-// checkInformationDisclosure checks for information disclosure in headers
-func checkInformationDisclosure(headers SecurityHeaders, timestamp time.Time) models.CheckResult {
+// softwareHeaders exist to advertise the software behind a site; other headers that fingerprint a
+// technology, such as WordPress's API Link, are only a leak when they carry a version
+var softwareHeaders = []string{"Server", "X-Powered-By", "X-Generator", "X-AspNet-Version", "X-AspNetMvc-Version"}
+
+// checkInformationDisclosure checks for information disclosure in headers, using the
+// technologies fingerprinted from those headers to name what is being revealed
+func checkInformationDisclosure(headers SecurityHeaders, httpHeaders http.Header, technologies []models.Technology, timestamp time.Time) models.CheckResult {
 	var issues []string
 	var good []string
 
-	// Check Server header
-	if headers.Server != "" {
-		if strings.Contains(strings.ToLower(headers.Server), "apache") ||
-			strings.Contains(strings.ToLower(headers.Server), "nginx") ||
-			strings.Contains(strings.ToLower(headers.Server), "iis") {
-			issues = append(issues, fmt.Sprintf("Server header reveals software: %s", headers.Server))
+	// Hosting and CDN headers are unavoidable; the application stack is what helps attackers
+	for _, tech := range technologies {
+		switch tech.Category {
+		case "web-server", "language", "framework", "cms":
+		default:
+			continue
 		}
-	} else {
-		good = append(good, "Server header hidden")
+
+		var revealing []string
+		versionExposed := false
+		for _, source := range tech.Sources {
+			header, ok := strings.CutPrefix(source, "header:")
+			if !ok {
+				continue
+			}
+			versioned := tech.Version != "" && strings.Contains(httpHeaders.Get(header), tech.Version)
+			if isSoftwareHeader(header) || versioned {
+				revealing = append(revealing, header)
+				versionExposed = versionExposed || versioned
+			}
+		}
+		if len(revealing) == 0 {
+			continue
+		}
+
+		name := tech.Name
+		if versionExposed {
+			name += " " + tech.Version + " (version exposed)"
+		}
+		issues = append(issues, fmt.Sprintf("%s header reveals %s", strings.Join(revealing, "/"), name))
 	}
 
-	// Check X-Powered-By header
-	if headers.XPoweredBy != "" {
-		issues = append(issues, fmt.Sprintf("X-Powered-By header reveals technology: %s", headers.XPoweredBy))
-	} else {
+	if headers.Server == "" {
+		good = append(good, "Server header hidden")
+	} else if !revealsHeader(technologies, "Server") && strings.ContainsAny(headers.Server, "0123456789") {
+		// Unknown software still shouldn't advertise its version
+		issues = append(issues, fmt.Sprintf("Server header reveals software version: %s", headers.Server))
+	}
+	if headers.XPoweredBy == "" {
 		good = append(good, "X-Powered-By header hidden")
+	} else if !revealsHeader(technologies, "X-Powered-By") {
+		issues = append(issues, fmt.Sprintf("X-Powered-By header reveals technology: %s", headers.XPoweredBy))
 	}
 
 	status := models.StatusPass
@@ -416,11 +473,36 @@ func checkInformationDisclosure(headers SecurityHeaders, timestamp time.Time) mo
 		details += "Issues: " + strings.Join(issues, ", ")
 	}
 
-	return models.CheckResult{
+	result := models.CheckResult{
 		Name:      "Information Disclosure",
 		Status:    status,
 		Message:   message,
 		Details:   details,
 		Timestamp: timestamp,
 	}
+	if len(technologies) > 0 {
+		result.Evidence = map[string]any{"technologies": technologies}
+	}
+
+	return result
+}
+
+// isSoftwareHeader reports whether header is one of the softwareHeaders
+func isSoftwareHeader(header string) bool {
+	for _, software := range softwareHeaders {
+		if strings.EqualFold(header, software) {
+			return true
+		}
+	}
+	return false
+}
+
+// revealsHeader reports whether any fingerprinted technology was identified from header
+func revealsHeader(technologies []models.Technology, header string) bool {
+	for _, tech := range technologies {
+		if containsString(tech.Sources, "header:"+header) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,248 @@
+package checker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// SecurityTxt holds the fields parsed from a security.txt file (RFC 9116)
+type SecurityTxt struct {
+	URL                string    `json:"url"`
+	Contact            []string  `json:"contact"`
+	Expires            time.Time `json:"expires,omitempty"`
+	Encryption         []string  `json:"encryption,omitempty"`
+	Acknowledgments    []string  `json:"acknowledgments,omitempty"`
+	Canonical          []string  `json:"canonical,omitempty"`
+	Policy             []string  `json:"policy,omitempty"`
+	Hiring             []string  `json:"hiring,omitempty"`
+	PreferredLanguages string    `json:"preferred_languages,omitempty"`
+	Signed             bool      `json:"signed"`
+	Errors             []string  `json:"errors,omitempty"`
+}
+
+// CheckSecurityTxt fetches /.well-known/security.txt (falling back to the legacy /security.txt)
+// and validates it against RFC 9116
+func CheckSecurityTxt(baseURL string) models.CheckResult {
+	start := time.Now()
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return models.CheckResult{
+			Name:      "Security.txt",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}
+	}
+
+	locations := []string{
+		fmt.Sprintf("%s://%s/.well-known/security.txt", u.Scheme, u.Host),
+		fmt.Sprintf("%s://%s/security.txt", u.Scheme, u.Host),
+	}
+
+	var content, foundAt string
+	for _, location := range locations {
+		body, ok := fetchSecurityTxt(location)
+		if ok {
+			content, foundAt = body, location
+			break
+		}
+	}
+
+	if foundAt == "" {
+		return models.CheckResult{
+			Name:      "Security.txt",
+			Status:    models.StatusFail,
+			Message:   "Missing security.txt",
+			Details:   "Publish /.well-known/security.txt with Contact and Expires fields so researchers can report vulnerabilities",
+			Timestamp: start,
+		}
+	}
+
+	txt := parseSecurityTxt(content)
+	txt.URL = foundAt
+
+	return validateSecurityTxt(txt, foundAt == locations[0], start)
+}
+
+// fetchSecurityTxt retrieves a security.txt candidate, accepting only plain-text 200 responses
+func fetchSecurityTxt(location string) (string, bool) {
+	resp, err := http.Get(location)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+
+	// Sites that serve their HTML shell for every path must not count as having a security.txt
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != 200 || (contentType != "" && !strings.HasPrefix(contentType, "text/plain")) {
+		return "", false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 32*1024))
+	if err != nil {
+		return "", false
+	}
+
+	return string(body), true
+}
+
+// parseSecurityTxt extracts fields from security.txt content, unwrapping a PGP cleartext signature if present
+func parseSecurityTxt(content string) SecurityTxt {
+	txt := SecurityTxt{}
+
+	if strings.Contains(content, "-----BEGIN PGP SIGNED MESSAGE-----") {
+		txt.Signed = strings.Contains(content, "-----BEGIN PGP SIGNATURE-----")
+		if end := strings.Index(content, "-----BEGIN PGP SIGNATURE-----"); end != -1 {
+			content = content[:end]
+		}
+	}
+
+	expiresCount := 0
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-----") || strings.HasPrefix(strings.ToLower(line), "hash:") {
+			continue
+		}
+		// Dash-escaped lines inside a cleartext signature
+		line = strings.TrimPrefix(line, "- ")
+
+		colon := strings.Index(line, ":")
+		if colon == -1 {
+			txt.Errors = append(txt.Errors, fmt.Sprintf("invalid line %q", line))
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(line[:colon]))
+		value := strings.TrimSpace(line[colon+1:])
+
+		switch field {
+		case "contact":
+			txt.Contact = append(txt.Contact, value)
+		case "expires":
+			expiresCount++
+			expires, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				txt.Errors = append(txt.Errors, fmt.Sprintf("Expires %q is not an RFC 3339 date", value))
+				continue
+			}
+			txt.Expires = expires
+		case "encryption":
+			txt.Encryption = append(txt.Encryption, value)
+		case "acknowledgments", "acknowledgements":
+			txt.Acknowledgments = append(txt.Acknowledgments, value)
+		case "canonical":
+			txt.Canonical = append(txt.Canonical, value)
+		case "policy":
+			txt.Policy = append(txt.Policy, value)
+		case "hiring":
+			txt.Hiring = append(txt.Hiring, value)
+		case "preferred-languages":
+			txt.PreferredLanguages = value
+		}
+	}
+
+	if expiresCount > 1 {
+		txt.Errors = append(txt.Errors, "Expires must appear only once")
+	}
+
+	return txt
+}
+
+// validateSecurityTxt turns a parsed security.txt into a check result
+func validateSecurityTxt(txt SecurityTxt, wellKnown bool, timestamp time.Time) models.CheckResult {
+	var problems, warnings []string
+	// outcome names the first problem, which gives an invalid file its finding ID
+	outcome := ""
+	problem := func(name string, message string) {
+		if outcome == "" {
+			outcome = name
+		}
+		problems = append(problems, message)
+	}
+
+	if len(txt.Contact) == 0 {
+		problem("no-contact", "missing required Contact field")
+	}
+	for _, contact := range txt.Contact {
+		if !strings.HasPrefix(contact, "mailto:") && !strings.HasPrefix(contact, "tel:") && !strings.HasPrefix(contact, "https://") {
+			warnings = append(warnings, fmt.Sprintf("Contact %q should be a mailto:, tel: or https:// URI", contact))
+		}
+	}
+
+	if txt.Expires.IsZero() {
+		problem("no-expires", "missing required Expires field")
+	} else if txt.Expires.Before(timestamp) {
+		problem("expired", fmt.Sprintf("expired on %s", txt.Expires.Format("2006-01-02")))
+	} else if txt.Expires.After(timestamp.AddDate(1, 0, 0)) {
+		warnings = append(warnings, "Expires is more than a year away")
+	}
+
+	for _, err := range txt.Errors {
+		problem("invalid", err)
+	}
+
+	for _, canonical := range txt.Canonical {
+		if !strings.HasPrefix(canonical, "https://") {
+			warnings = append(warnings, fmt.Sprintf("Canonical %q is not an HTTPS URL", canonical))
+		}
+	}
+	if len(txt.Canonical) > 0 && !containsString(txt.Canonical, txt.URL) {
+		warnings = append(warnings, fmt.Sprintf("served from %s, which isn't listed as Canonical", txt.URL))
+	}
+	if !strings.HasPrefix(txt.URL, "https://") {
+		warnings = append(warnings, "served over plain HTTP")
+	}
+	if !wellKnown {
+		warnings = append(warnings, "only found at the legacy /security.txt location; move it to /.well-known/security.txt")
+	}
+	if !txt.Signed {
+		warnings = append(warnings, "not PGP signed")
+	}
+
+	evidence := map[string]any{
+		"security_txt": txt,
+	}
+	details := fmt.Sprintf("Found at %s with %d contact(s)", txt.URL, len(txt.Contact))
+
+	if len(problems) > 0 {
+		return models.CheckResult{
+			Name:      "Security.txt",
+			Status:    models.StatusFail,
+			Message:   "security.txt is invalid",
+			Outcome:   outcome,
+			Details:   details + ". Problems: " + strings.Join(append(problems, warnings...), "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	if len(warnings) > 0 {
+		return models.CheckResult{
+			Name:      "Security.txt",
+			Status:    models.StatusWarning,
+			Message:   "security.txt present but could be improved",
+			Details:   details + ". Recommendations: " + strings.Join(warnings, "; "),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Security.txt",
+		Status:    models.StatusPass,
+		Message:   "security.txt present and valid",
+		Details:   details + fmt.Sprintf(", expires %s", txt.Expires.Format("2006-01-02")),
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
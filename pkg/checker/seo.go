@@ -40,6 +40,7 @@ func CheckSEOMetadata(htmlContent string) []models.CheckResult {
 			Name:      "SEO Metadata",
 			Status:    models.StatusFail,
 			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
 			Details:   err.Error(),
 			Timestamp: start,
 		}}
@@ -71,6 +72,7 @@ func CheckSEOMetadataFromURL(url string) []models.CheckResult {
 			Name:      "SEO Metadata",
 			Status:    models.StatusFail,
 			Message:   "Failed to fetch page",
+			Outcome:   "unreachable",
 			Details:   err.Error(),
 			Timestamp: start,
 		}}
@@ -82,6 +84,7 @@ func CheckSEOMetadataFromURL(url string) []models.CheckResult {
 			Name:      "SEO Metadata",
 			Status:    models.StatusFail,
 			Message:   fmt.Sprintf("HTTP %d response", resp.StatusCode),
+			Outcome:   "http-error",
 			Details:   fmt.Sprintf("Unable to fetch page content from %s", url),
 			Timestamp: start,
 		}}
@@ -93,6 +96,7 @@ func CheckSEOMetadataFromURL(url string) []models.CheckResult {
 			Name:      "SEO Metadata",
 			Status:    models.StatusFail,
 			Message:   "Failed to read page content",
+			Outcome:   "unreachable",
 			Details:   err.Error(),
 			Timestamp: start,
 		}}
@@ -214,6 +218,7 @@ func checkTitle(title string, timestamp time.Time) models.CheckResult {
 			Name:      "Title Tag",
 			Status:    models.StatusWarning,
 			Message:   "Title too short",
+			Outcome:   "too-short",
 			Details:   fmt.Sprintf("Title is %d characters. Recommended: 30-60 characters", length),
 			Timestamp: timestamp,
 		}
@@ -224,6 +229,7 @@ func checkTitle(title string, timestamp time.Time) models.CheckResult {
 			Name:      "Title Tag",
 			Status:    models.StatusWarning,
 			Message:   "Title too long",
+			Outcome:   "too-long",
 			Details:   fmt.Sprintf("Title is %d characters. May be truncated in search results", length),
 			Timestamp: timestamp,
 		}
@@ -256,6 +262,7 @@ func checkMetaDescription(description string, timestamp time.Time) models.CheckR
 			Name:      "Meta Description",
 			Status:    models.StatusWarning,
 			Message:   "Meta description too short",
+			Outcome:   "too-short",
 			Details:   fmt.Sprintf("Description is %d characters. Recommended: 120-160 characters", length),
 			Timestamp: timestamp,
 		}
@@ -266,6 +273,7 @@ func checkMetaDescription(description string, timestamp time.Time) models.CheckR
 			Name:      "Meta Description",
 			Status:    models.StatusWarning,
 			Message:   "Meta description too long",
+			Outcome:   "too-long",
 			Details:   fmt.Sprintf("Description is %d characters. May be truncated in search results", length),
 			Timestamp: timestamp,
 		}
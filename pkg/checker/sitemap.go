@@ -23,6 +23,7 @@ func CheckSitemap(baseURL string, robotsContent string) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusFail,
 			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
 			Details:   err.Error(),
 			Timestamp: start,
 		}
@@ -85,6 +86,7 @@ func testSitemapURL(sitemapURL string, startTime time.Time) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusFail,
 			Message:   "Failed to fetch sitemap",
+			Outcome:   "unreachable",
 			Details:   fmt.Sprintf("Error accessing %s: %v", sitemapURL, err),
 			Timestamp: startTime,
 		}
@@ -98,6 +100,7 @@ func testSitemapURL(sitemapURL string, startTime time.Time) models.CheckResult {
 				Name:      "Sitemap",
 				Status:    models.StatusFail,
 				Message:   "Sitemap not found",
+				Outcome:   "missing",
 				Details:   fmt.Sprintf("HTTP %d for %s", resp.StatusCode, sitemapURL),
 				Timestamp: startTime,
 			}
@@ -106,6 +109,7 @@ func testSitemapURL(sitemapURL string, startTime time.Time) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusWarning,
 			Message:   fmt.Sprintf("Unexpected status code: %d", resp.StatusCode),
+			Outcome:   "unexpected-status",
 			Details:   fmt.Sprintf("Sitemap at %s returned HTTP %d", sitemapURL, resp.StatusCode),
 			Timestamp: startTime,
 		}
@@ -118,6 +122,7 @@ func testSitemapURL(sitemapURL string, startTime time.Time) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusWarning,
 			Message:   "Could not read sitemap content",
+			Outcome:   "unreachable",
 			Details:   fmt.Sprintf("Error reading content from %s: %v", sitemapURL, err),
 			Timestamp: startTime,
 		}
@@ -146,6 +151,7 @@ func testSitemapURL(sitemapURL string, startTime time.Time) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusWarning,
 			Message:   "Sitemap XML doesn't contain expected elements",
+			Outcome:   "unexpected-structure",
 			Details:   fmt.Sprintf("File at %s appears to be XML but missing sitemap elements", sitemapURL),
 			Timestamp: startTime,
 		}
@@ -182,6 +188,7 @@ func CheckSitemapWithRobotsURL(baseURL string) models.CheckResult {
 			Name:      "Sitemap",
 			Status:    models.StatusFail,
 			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
 			Details:   err.Error(),
 			Timestamp: start,
 		}
@@ -0,0 +1,277 @@
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// integrityPattern matches a single SRI hash token such as sha384-<base64>
+var integrityPattern = regexp.MustCompile(`^(sha256|sha384|sha512)-[A-Za-z0-9+/]+={0,2}$`)
+
+// ThirdPartyDomain summarises the resources a page loads from one third-party domain
+type ThirdPartyDomain struct {
+	Domain      string   `json:"domain"`
+	Scripts     int      `json:"scripts"`
+	Stylesheets int      `json:"stylesheets"`
+	WithSRI     int      `json:"with_sri"`
+	Insecure    int      `json:"insecure"`
+	URLs        []string `json:"urls"`
+}
+
+// CheckSubresourceIntegrity inventories third-party scripts and stylesheets in the HTML
+// and reports Subresource Integrity coverage for cross-origin resources
+func CheckSubresourceIntegrity(pageURL string, htmlContent string) []models.CheckResult {
+	start := time.Now()
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Subresource Integrity",
+			Status:    models.StatusFail,
+			Message:   "Invalid URL provided",
+			Outcome:   "invalid-url",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Subresource Integrity",
+			Status:    models.StatusFail,
+			Message:   "Failed to parse HTML",
+			Outcome:   "unparsable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	var resources []Subresource
+	for _, res := range extractSubresources(doc, base) {
+		if res.Kind == "script" || res.Kind == "stylesheet" {
+			resources = append(resources, res)
+		}
+	}
+
+	return []models.CheckResult{
+		checkSRICoverage(base, resources, start),
+		checkThirdPartyInventory(base, resources, start),
+	}
+}
+
+// CheckSubresourceIntegrityFromURL fetches HTML content from URL and checks SRI coverage
+func CheckSubresourceIntegrityFromURL(pageURL string) []models.CheckResult {
+	start := time.Now()
+
+	htmlContent, finalURL, err := fetchHTML(pageURL)
+	if err != nil {
+		return []models.CheckResult{{
+			Name:      "Subresource Integrity",
+			Status:    models.StatusFail,
+			Message:   "Failed to fetch page",
+			Outcome:   "unreachable",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	return CheckSubresourceIntegrity(finalURL, htmlContent)
+}
+
+// checkSRICoverage validates integrity and crossorigin attributes on cross-origin scripts and styles
+func checkSRICoverage(base *url.URL, resources []Subresource, timestamp time.Time) models.CheckResult {
+	var crossOrigin, covered int
+	var missingScripts, missingStyles, missingCORS, invalid []string
+
+	for _, res := range resources {
+		if !isCrossOrigin(base, res.URL) {
+			continue
+		}
+		crossOrigin++
+
+		if res.Integrity == "" {
+			if res.Kind == "script" {
+				missingScripts = append(missingScripts, res.URL)
+			} else {
+				missingStyles = append(missingStyles, res.URL)
+			}
+			continue
+		}
+
+		valid := true
+		for _, token := range strings.Fields(res.Integrity) {
+			if !integrityPattern.MatchString(token) {
+				valid = false
+			}
+		}
+		if !valid {
+			invalid = append(invalid, res.URL)
+			continue
+		}
+
+		// Without crossorigin the browser fetches in no-cors mode and cannot verify the hash
+		if !res.HasCrossOrigin {
+			missingCORS = append(missingCORS, res.URL)
+			continue
+		}
+		covered++
+	}
+
+	evidence := map[string]any{
+		"cross_origin_resources": crossOrigin,
+		"with_integrity":         covered,
+		"scripts_without_sri":    missingScripts,
+		"styles_without_sri":     missingStyles,
+		"missing_crossorigin":    missingCORS,
+		"invalid_integrity":      invalid,
+	}
+
+	if crossOrigin == 0 {
+		return models.CheckResult{
+			Name:      "Subresource Integrity",
+			Status:    models.StatusPass,
+			Message:   "No cross-origin scripts or stylesheets",
+			Details:   "All scripts and stylesheets are served from the page's own origin",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	var issues []string
+	if len(missingScripts) > 0 {
+		issues = append(issues, fmt.Sprintf("%d scripts without integrity", len(missingScripts)))
+	}
+	if len(missingStyles) > 0 {
+		issues = append(issues, fmt.Sprintf("%d stylesheets without integrity", len(missingStyles)))
+	}
+	if len(missingCORS) > 0 {
+		issues = append(issues, fmt.Sprintf("%d resources with integrity but no crossorigin attribute", len(missingCORS)))
+	}
+	if len(invalid) > 0 {
+		issues = append(issues, fmt.Sprintf("%d resources with malformed integrity values", len(invalid)))
+	}
+
+	details := fmt.Sprintf("%d of %d cross-origin scripts and stylesheets protected", covered, crossOrigin)
+
+	if len(issues) == 0 {
+		return models.CheckResult{
+			Name:      "Subresource Integrity",
+			Status:    models.StatusPass,
+			Message:   "All cross-origin resources use SRI",
+			Details:   details,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	status := models.StatusWarning
+	message := "Some cross-origin resources lack SRI"
+	if len(missingScripts) > 0 || len(invalid) > 0 {
+		status = models.StatusFail
+		message = "Cross-origin scripts loaded without integrity protection"
+	}
+
+	return models.CheckResult{
+		Name:      "Subresource Integrity",
+		Status:    status,
+		Message:   message,
+		Details:   details + ". Issues: " + strings.Join(issues, ", ") + ". Add integrity and crossorigin=\"anonymous\" attributes to third-party tags",
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
+
+// checkThirdPartyInventory groups third-party scripts and styles by registrable domain
+func checkThirdPartyInventory(base *url.URL, resources []Subresource, timestamp time.Time) models.CheckResult {
+	siteDomain := registrableDomain(base.Hostname())
+	domains := make(map[string]*ThirdPartyDomain)
+
+	for _, res := range resources {
+		u, err := url.Parse(res.URL)
+		if err != nil {
+			continue
+		}
+		domain := registrableDomain(u.Hostname())
+		if domain == siteDomain {
+			continue
+		}
+
+		entry, exists := domains[domain]
+		if !exists {
+			entry = &ThirdPartyDomain{Domain: domain}
+			domains[domain] = entry
+		}
+		if res.Kind == "script" {
+			entry.Scripts++
+		} else {
+			entry.Stylesheets++
+		}
+		if res.Integrity != "" {
+			entry.WithSRI++
+		}
+		if u.Scheme == "http" {
+			entry.Insecure++
+		}
+		entry.URLs = append(entry.URLs, res.URL)
+	}
+
+	var inventory []ThirdPartyDomain
+	var names []string
+	insecure := 0
+	for _, entry := range domains {
+		inventory = append(inventory, *entry)
+		insecure += entry.Insecure
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Domain < inventory[j].Domain
+	})
+	for _, entry := range inventory {
+		names = append(names, entry.Domain)
+	}
+
+	evidence := map[string]any{
+		"domains":   names,
+		"inventory": inventory,
+	}
+
+	if len(inventory) == 0 {
+		return models.CheckResult{
+			Name:      "Third-Party Resources",
+			Status:    models.StatusPass,
+			Message:   "No third-party scripts or stylesheets",
+			Details:   fmt.Sprintf("All scripts and stylesheets are served from %s", siteDomain),
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	details := fmt.Sprintf("Loads scripts or styles from %d third-party domains: %s", len(names), strings.Join(names, ", "))
+
+	if insecure > 0 {
+		return models.CheckResult{
+			Name:      "Third-Party Resources",
+			Status:    models.StatusWarning,
+			Message:   fmt.Sprintf("%d third-party resources loaded over plain HTTP", insecure),
+			Details:   details + ". Load third-party resources over HTTPS",
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+
+	return models.CheckResult{
+		Name:      "Third-Party Resources",
+		Status:    models.StatusPass,
+		Message:   fmt.Sprintf("%d third-party domains inventoried", len(names)),
+		Details:   details,
+		Evidence:  evidence,
+		Timestamp: timestamp,
+	}
+}
@@ -0,0 +1,186 @@
+package checker
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// timingThreshold holds the pass and warning limits for one timing phase
+type timingThreshold struct {
+	Name    string
+	Pass    time.Duration // At or below: pass
+	Warning time.Duration // At or below: warning; above: fail
+	Advice  string
+}
+
+// Thresholds follow common web performance budgets; TTFB matches the web.dev "good"/"poor" bands
+var (
+	dnsThreshold      = timingThreshold{"DNS Lookup Time", 100 * time.Millisecond, 300 * time.Millisecond, "use a faster DNS provider or longer TTLs"}
+	connectThreshold  = timingThreshold{"TCP Connect Time", 100 * time.Millisecond, 300 * time.Millisecond, "serve from a location closer to users or put a CDN in front"}
+	tlsThreshold      = timingThreshold{"TLS Handshake Time", 200 * time.Millisecond, 500 * time.Millisecond, "enable TLS 1.3, session resumption and OCSP stapling"}
+	ttfbThreshold     = timingThreshold{"Time to First Byte", 800 * time.Millisecond, 1800 * time.Millisecond, "cache rendered pages and profile slow server-side work"}
+	transferThreshold = timingThreshold{"Content Transfer Time", 500 * time.Millisecond, 2 * time.Second, "compress the document and reduce its size"}
+)
+
+// requestTrace records the httptrace events of the most recent request in a redirect chain
+type requestTrace struct {
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	wroteRequest, firstByte   time.Time
+}
+
+// MeasureResponseTiming fetches the page on a fresh connection and returns the timing of each
+// phase of the main document request. Redirects are followed and counted in Total only.
+func MeasureResponseTiming(pageURL string) (*models.Timing, error) {
+	var trace requestTrace
+	clientTrace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			// A new hop in a redirect chain starts here; earlier events belong to the previous hop
+			trace = requestTrace{}
+		},
+		DNSStart:             func(httptrace.DNSStartInfo) { trace.dnsStart = time.Now() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { trace.dnsDone = time.Now() },
+		ConnectStart:         func(string, string) { trace.connectStart = time.Now() },
+		ConnectDone:          func(string, string, error) { trace.connectDone = time.Now() },
+		TLSHandshakeStart:    func() { trace.tlsStart = time.Now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { trace.tlsDone = time.Now() },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { trace.wroteRequest = time.Now() },
+		GotFirstResponseByte: func() { trace.firstByte = time.Now() },
+	}
+
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), clientTrace))
+
+	redirects := 0
+	// Keep-alives are disabled so every measurement includes connection setup
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, DisableKeepAlives: true},
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			redirects = len(via)
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	end := time.Now()
+
+	if trace.firstByte.IsZero() {
+		trace.firstByte = end
+	}
+	return &models.Timing{
+		DNSLookup:       milliseconds(trace.dnsStart, trace.dnsDone),
+		TCPConnect:      milliseconds(trace.connectStart, trace.connectDone),
+		TLSHandshake:    milliseconds(trace.tlsStart, trace.tlsDone),
+		TimeToFirstByte: milliseconds(trace.wroteRequest, trace.firstByte),
+		ContentTransfer: milliseconds(trace.firstByte, end),
+		Total:           milliseconds(start, end),
+		Redirects:       redirects,
+		Bytes:           n,
+	}, nil
+}
+
+// EvaluateResponseTiming turns a timing breakdown into pass/warning/fail results; phases that didn't
+// happen (no TLS on plain HTTP, no DNS for IP literals) are skipped
+func EvaluateResponseTiming(timing *models.Timing, timestamp time.Time) []models.CheckResult {
+	evidence := map[string]any{"timing": timing}
+
+	var results []models.CheckResult
+	if timing.DNSLookup > 0 {
+		results = append(results, evaluateTimingPhase(dnsThreshold, timing.DNSLookup, evidence, timestamp))
+	}
+	results = append(results, evaluateTimingPhase(connectThreshold, timing.TCPConnect, evidence, timestamp))
+	if timing.TLSHandshake > 0 {
+		results = append(results, evaluateTimingPhase(tlsThreshold, timing.TLSHandshake, evidence, timestamp))
+	}
+	results = append(results, evaluateTimingPhase(ttfbThreshold, timing.TimeToFirstByte, evidence, timestamp))
+	results = append(results, evaluateTimingPhase(transferThreshold, timing.ContentTransfer, evidence, timestamp))
+
+	return results
+}
+
+// CheckResponseTiming measures the main document request and evaluates each phase. When the page
+// can't be fetched the timing is nil and a single failing result explains why.
+func CheckResponseTiming(pageURL string) (*models.Timing, []models.CheckResult) {
+	start := time.Now()
+
+	timing, err := MeasureResponseTiming(pageURL)
+	if err != nil {
+		return nil, []models.CheckResult{{
+			Name:      "Response Timing",
+			Status:    models.StatusFail,
+			Message:   "Failed to fetch page",
+			Details:   err.Error(),
+			Timestamp: start,
+		}}
+	}
+
+	return timing, EvaluateResponseTiming(timing, start)
+}
+
+// evaluateTimingPhase compares one phase against its threshold
+func evaluateTimingPhase(threshold timingThreshold, ms float64, evidence map[string]any, timestamp time.Time) models.CheckResult {
+	elapsed := time.Duration(ms * float64(time.Millisecond))
+	details := fmt.Sprintf("%.0fms (good ≤ %dms, poor > %dms)", ms, threshold.Pass.Milliseconds(), threshold.Warning.Milliseconds())
+
+	switch {
+	case elapsed <= threshold.Pass:
+		return models.CheckResult{
+			Name:      threshold.Name,
+			Status:    models.StatusPass,
+			Message:   fmt.Sprintf("%s is fast", threshold.Name),
+			Details:   details,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	case elapsed <= threshold.Warning:
+		return models.CheckResult{
+			Name:      threshold.Name,
+			Status:    models.StatusWarning,
+			Message:   fmt.Sprintf("%s could be faster", threshold.Name),
+			Details:   details + ". Consider: " + threshold.Advice,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	default:
+		return models.CheckResult{
+			Name:      threshold.Name,
+			Status:    models.StatusFail,
+			Message:   fmt.Sprintf("%s is slow", threshold.Name),
+			Details:   details + ". Consider: " + threshold.Advice,
+			Evidence:  evidence,
+			Timestamp: timestamp,
+		}
+	}
+}
+
+// milliseconds returns the time between two trace events, or 0 if either didn't happen
+func milliseconds(from time.Time, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return 0
+	}
+	return float64(to.Sub(from).Microseconds()) / 1000
+}
@@ -7,11 +7,29 @@ import (
 )
 
 type CheckResult struct {
-	Name      string    `json:"name"`
-	Status    Status    `json:"status"`
-	Message   string    `json:"message"`
-	Details   string    `json:"details,omitempty"`
-	Timestamp time.Time `json:"timestamp"`
+	Name      string         `json:"name"`
+	Status    Status         `json:"status"`
+	Message   string         `json:"message"`
+	Details   string         `json:"details,omitempty"`
+	Evidence  map[string]any `json:"evidence,omitempty"` // Structured data backing the result
+	Category  string         `json:"category,omitempty"` // Report section, e.g. security, seo, performance
+	Duration  time.Duration  `json:"duration,omitempty"` // Time spent on the check; shared evenly by results of the same check
+	Timestamp time.Time      `json:"timestamp"`
+
+	// Outcome names what the check found, e.g. "expired"; it becomes the last part of the ID. Checks set
+	// it when one status covers several findings, otherwise the catalog's outcome for the status is used.
+	Outcome string `json:"-"`
+
+	// Catalog metadata of built-in checks; severity, remediation and references are only set on findings
+	ID          string   `json:"id,omitempty"`          // Stable finding ID, e.g. security.hsts.missing
+	Rule        string   `json:"rule,omitempty"`        // Stable ID of the check, e.g. security.hsts
+	Severity    Severity `json:"severity,omitempty"`    // Impact of the finding, independent of its status
+	Remediation string   `json:"remediation,omitempty"` // How to fix the finding
+	References  []string `json:"references,omitempty"`  // Documentation links for the fix
+
+	// Suppressed results are accepted findings: still reported, but left out of scores and exit codes
+	Suppressed        bool   `json:"suppressed,omitempty"`
+	SuppressionReason string `json:"suppression_reason,omitempty"`
 }
 
 type Status string
@@ -22,12 +40,65 @@ const (
 	StatusFail    Status = "fail"
 )
 
+// Severity ranks the impact of a warning or failure, from info to critical
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
 type WebsiteReport struct {
-	URL          string        `json:"url"`
-	Timestamp    time.Time     `json:"timestamp"`
-	Duration     time.Duration `json:"duration"`
-	Results      []CheckResult `json:"results"`
-	OverallScore int           `json:"overall_score"`
+	URL            string          `json:"url"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Duration       time.Duration   `json:"duration"`
+	Results        []CheckResult   `json:"results"`
+	OverallScore   int             `json:"overall_score"`
+	Grade          string          `json:"grade,omitempty"`           // Letter grade of OverallScore, A+ to F
+	ScoringProfile string          `json:"scoring_profile,omitempty"` // Profile the score was computed with
+	Passed         int             `json:"passed"`
+	Warnings       int             `json:"warnings"`
+	Failed         int             `json:"failed"`
+	Suppressed     int             `json:"suppressed,omitempty"`
+	Categories     []CategoryScore `json:"categories,omitempty"` // Per-category scores in report order
+	Technologies   []Technology    `json:"technologies,omitempty"`
+	Timing         *Timing         `json:"timing,omitempty"`
+
+	SuppressionWarnings []string `json:"suppression_warnings,omitempty"` // Expired or soon-expiring suppressions
+}
+
+// CategoryScore is the score and result tally of one report category
+type CategoryScore struct {
+	Category   string `json:"category"`
+	Score      int    `json:"score"`
+	Grade      string `json:"grade"`
+	Passed     int    `json:"passed"`
+	Warnings   int    `json:"warnings"`
+	Failed     int    `json:"failed"`
+	Suppressed int    `json:"suppressed,omitempty"`
+}
+
+// Timing is the network timing breakdown of the main document request, in milliseconds
+type Timing struct {
+	DNSLookup       float64 `json:"dns_lookup_ms"`
+	TCPConnect      float64 `json:"tcp_connect_ms"`
+	TLSHandshake    float64 `json:"tls_handshake_ms"`
+	TimeToFirstByte float64 `json:"ttfb_ms"` // From sending the request to the first response byte
+	ContentTransfer float64 `json:"content_transfer_ms"`
+	Total           float64 `json:"total_ms"` // Includes any redirects
+	Redirects       int     `json:"redirects"`
+	Bytes           int64   `json:"bytes"`
+}
+
+// Technology represents a product detected on the audited site (CMS, framework, CDN, ...)
+type Technology struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"` // cms, framework, language, web-server, cdn, analytics, hosting
+	Version  string   `json:"version,omitempty"`
+	Sources  []string `json:"sources"` // Where it was detected, e.g. header:Server, meta:generator
 }
 
 // WebsiteCheck represents a stored check in the database.
@@ -78,5 +149,6 @@ type IssueDetail struct {
 type LeaderboardEntry struct {
 	URL          string    `json:"url"`
 	OverallScore int       `json:"overall_score"`
+	Grade        string    `json:"grade,omitempty"`
 	Timestamp    time.Time `json:"timestamp"`
 }
@@ -0,0 +1,194 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+//go:embed data/checks.json
+var defaultCheckCatalog []byte
+
+// severityOrder lists severities from least to most severe
+var severityOrder = []models.Severity{
+	models.SeverityInfo,
+	models.SeverityLow,
+	models.SeverityMedium,
+	models.SeverityHigh,
+	models.SeverityCritical,
+}
+
+// knownSeverity reports whether severity is one of the severity levels
+func knownSeverity(severity models.Severity) bool {
+	for _, known := range severityOrder {
+		if known == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckDefinition is the catalog entry of a built-in check. Its findings get the ID
+// <id>.<outcome>, where the outcome names what went wrong, e.g. security.hsts.missing.
+type CheckDefinition struct {
+	ID          string          `json:"id"`                // Stable check ID, <category>.<check>
+	Name        string          `json:"name"`              // Result name, without any per-finding suffix
+	Severity    models.Severity `json:"severity"`          // Severity of failures; warnings rank one level lower
+	Fail        string          `json:"fail,omitempty"`    // Outcome of failures; defaults to "fail"
+	Warning     string          `json:"warning,omitempty"` // Outcome of warnings; defaults to "warning"
+	Remediation string          `json:"remediation"`
+	References  []string        `json:"references,omitempty"`
+}
+
+var (
+	checkCatalogOnce sync.Once
+	checkCatalog     []CheckDefinition
+	checksByName     map[string]*CheckDefinition
+)
+
+// CheckCatalog returns the definitions of every built-in check, embedded in the binary
+func CheckCatalog() []CheckDefinition {
+	loadCheckCatalog()
+	return append([]CheckDefinition(nil), checkCatalog...)
+}
+
+// LookupCheck returns the catalog entry for a result name, matching the full name first and then the
+// name without its per-finding suffix, e.g. "Sensitive File Exposure (/.env)"
+func LookupCheck(name string) (CheckDefinition, bool) {
+	definition := lookupCheck(name)
+	if definition == nil {
+		return CheckDefinition{}, false
+	}
+	return *definition, true
+}
+
+// DescribeResults fills the catalog metadata of results in place; see DescribeResult
+func DescribeResults(results []models.CheckResult) {
+	for i := range results {
+		results[i] = DescribeResult(results[i])
+	}
+}
+
+// DescribeResult sets the stable ID and rule of a built-in check's result, and the severity,
+// remediation and references of its warnings and failures. The ID ends in the outcome the check set,
+// or the catalog's outcome for the status. Results that already carry an ID, and results of checks
+// missing from the catalog, are returned unchanged.
+func DescribeResult(result models.CheckResult) models.CheckResult {
+	definition := lookupCheck(result.Name)
+	if definition == nil || result.ID != "" {
+		return result
+	}
+
+	result.Rule = definition.ID
+	outcome := result.Outcome
+	if outcome == "" {
+		outcome = definition.outcome(result.Status)
+	}
+	result.ID = definition.ID + "." + outcome
+	if result.Status == models.StatusFail || result.Status == models.StatusWarning {
+		result.Severity = definition.severity(result.Status)
+		result.Remediation = definition.Remediation
+		result.References = definition.References
+	}
+	return result
+}
+
+// outcome names the result of the check for a status
+func (d *CheckDefinition) outcome(status models.Status) string {
+	switch {
+	case status == models.StatusPass:
+		return "ok"
+	case status == models.StatusFail && d.Fail != "":
+		return d.Fail
+	case status == models.StatusWarning && d.Warning != "":
+		return d.Warning
+	default:
+		return string(status)
+	}
+}
+
+// severity returns the severity of a finding: the check's severity for failures, one level lower for warnings
+func (d *CheckDefinition) severity(status models.Status) models.Severity {
+	if status != models.StatusWarning {
+		return d.Severity
+	}
+	for i, severity := range severityOrder {
+		if severity == d.Severity && i > 0 {
+			return severityOrder[i-1]
+		}
+	}
+	return d.Severity
+}
+
+// matchKey identifies a result across reports by its check ID and per-finding suffix, falling back to
+// the category and name for checks missing from the catalog
+func matchKey(result models.CheckResult) string {
+	if definition := lookupCheck(result.Name); definition != nil {
+		return definition.ID + strings.TrimPrefix(result.Name, definition.Name)
+	}
+	category := result.Category
+	if category == "" {
+		category = "other"
+	}
+	return category + "/" + result.Name
+}
+
+// lookupCheck finds the catalog entry for a result name
+func lookupCheck(name string) *CheckDefinition {
+	loadCheckCatalog()
+	if definition, ok := checksByName[name]; ok {
+		return definition
+	}
+	return checksByName[baseCheckName(name)]
+}
+
+// loadCheckCatalog parses the embedded catalog once
+func loadCheckCatalog() {
+	checkCatalogOnce.Do(func() {
+		definitions, err := parseCheckCatalog(defaultCheckCatalog)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded check catalog: %v", err))
+		}
+		checkCatalog = definitions
+		checksByName = make(map[string]*CheckDefinition, len(definitions))
+		for i := range checkCatalog {
+			checksByName[checkCatalog[i].Name] = &checkCatalog[i]
+		}
+	})
+}
+
+// parseCheckCatalog decodes and validates a check catalog
+func parseCheckCatalog(data []byte) ([]CheckDefinition, error) {
+	var catalog struct {
+		Checks []CheckDefinition `json:"checks"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse check catalog: %w", err)
+	}
+
+	ids := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, definition := range catalog.Checks {
+		if !strings.Contains(definition.ID, ".") || strings.ToLower(definition.ID) != definition.ID {
+			return nil, fmt.Errorf("check %q: id %q must be a lowercase <category>.<check>", definition.Name, definition.ID)
+		}
+		if ids[definition.ID] || names[definition.Name] {
+			return nil, fmt.Errorf("check %q (%s) is listed twice", definition.Name, definition.ID)
+		}
+		ids[definition.ID] = true
+		names[definition.Name] = true
+
+		if !knownSeverity(definition.Severity) {
+			return nil, fmt.Errorf("check %s: unknown severity %q", definition.ID, definition.Severity)
+		}
+		if definition.Remediation == "" {
+			return nil, fmt.Errorf("check %s: remediation is required", definition.ID)
+		}
+	}
+
+	return catalog.Checks, nil
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// csvHeader names the columns written by CSVReporter
+var csvHeader = []string{"url", "overall_score", "grade", "scoring_profile", "category", "check", "id", "severity", "status", "suppressed", "message", "details", "duration_ms", "timestamp"}
+
+// CSVReporter writes one row per URL × check, for spreadsheets and bulk analysis. It can be given
+// many reports in turn (from several goroutines) and writes the header once.
+type CSVReporter struct {
+	writer        *csv.Writer
+	mu            sync.Mutex
+	headerWritten bool
+}
+
+// NewCSVReporter creates a new CSV reporter writing to writer
+func NewCSVReporter(writer io.Writer) *CSVReporter {
+	return &CSVReporter{
+		writer: csv.NewWriter(writer),
+	}
+}
+
+// GenerateReport writes the rows for individual check results grouped by checker
+func (r *CSVReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport appends the rows for one report and flushes them
+func (r *CSVReporter) WriteReport(report models.WebsiteReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.headerWritten {
+		if err := r.writer.Write(csvHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		r.headerWritten = true
+	}
+
+	score := strconv.Itoa(report.OverallScore)
+	for _, result := range report.Results {
+		timestamp := ""
+		if !result.Timestamp.IsZero() {
+			timestamp = result.Timestamp.UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			report.URL,
+			score,
+			Grade(report.OverallScore),
+			report.ScoringProfile,
+			result.Category,
+			result.Name,
+			result.ID,
+			string(result.Severity),
+			string(result.Status),
+			strconv.FormatBool(result.Suppressed),
+			result.Message,
+			result.Details,
+			strconv.FormatInt(result.Duration.Milliseconds(), 10),
+			timestamp,
+		}
+		if err := r.writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	r.writer.Flush()
+	if err := r.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// ReportDiff lists what changed between two audits: score deltas overall and per category, checks
+// that got worse or better, and checks that only one of the audits ran
+type ReportDiff struct {
+	Old         DiffSide       `json:"old"`
+	New         DiffSide       `json:"new"`
+	ScoreDelta  int            `json:"score_delta"`
+	Comparable  bool           `json:"comparable"` // Both reports were scored with the same profile
+	Categories  []CategoryDiff `json:"categories"`
+	Regressions []CheckChange  `json:"regressions"`
+	Fixes       []CheckChange  `json:"fixes"`
+	Added       []CheckChange  `json:"added"`
+	Removed     []CheckChange  `json:"removed"`
+}
+
+// DiffSide identifies one of the compared reports
+type DiffSide struct {
+	URL            string    `json:"url"`
+	Timestamp      time.Time `json:"timestamp"`
+	Score          int       `json:"score"`
+	Grade          string    `json:"grade"`
+	ScoringProfile string    `json:"scoring_profile,omitempty"`
+}
+
+// CategoryDiff is the score change of one category; a category missing from one report scores 0 there
+type CategoryDiff struct {
+	Category string `json:"category"`
+	OldScore int    `json:"old_score"`
+	NewScore int    `json:"new_score"`
+	Delta    int    `json:"delta"`
+	Added    bool   `json:"added,omitempty"`   // Only in the new report
+	Removed  bool   `json:"removed,omitempty"` // Only in the old report
+}
+
+// CheckChange is a check whose status differs between the reports. OldStatus is empty for added
+// checks and NewStatus for removed ones; Message is from the newest report that has the check.
+type CheckChange struct {
+	Category  string        `json:"category"`
+	Name      string        `json:"name"`
+	ID        string        `json:"id,omitempty"` // Finding ID in the newest report that has the check
+	OldStatus models.Status `json:"old_status,omitempty"`
+	NewStatus models.Status `json:"new_status,omitempty"`
+	Message   string        `json:"message"`
+}
+
+// DiffReports compares an earlier report with a later one. Checks are matched by their catalog ID and
+// per-finding suffix, or by category and name when the catalog doesn't know them; a check reported
+// more than once is matched by its position among the repeats.
+func DiffReports(previous, current models.WebsiteReport) ReportDiff {
+	diff := ReportDiff{
+		Old:         newDiffSide(previous),
+		New:         newDiffSide(current),
+		ScoreDelta:  current.OverallScore - previous.OverallScore,
+		Comparable:  previous.ScoringProfile == current.ScoringProfile,
+		Regressions: []CheckChange{},
+		Fixes:       []CheckChange{},
+		Added:       []CheckChange{},
+		Removed:     []CheckChange{},
+	}
+
+	oldScores := CategoryScores(previous)
+	newScores := CategoryScores(current)
+	categories := make(map[string][]models.CheckResult)
+	for key := range oldScores {
+		categories[key] = nil
+	}
+	for key := range newScores {
+		categories[key] = nil
+	}
+	for _, key := range orderedCategories(categories) {
+		oldScore, inOld := oldScores[key]
+		newScore, inNew := newScores[key]
+		diff.Categories = append(diff.Categories, CategoryDiff{
+			Category: key,
+			OldScore: oldScore.Score,
+			NewScore: newScore.Score,
+			Delta:    newScore.Score - oldScore.Score,
+			Added:    !inOld,
+			Removed:  !inNew,
+		})
+	}
+
+	oldResults := indexResults(previous.Results)
+	newResults := indexResults(current.Results)
+	for _, key := range newResults.order {
+		result := newResults.byKey[key]
+		change := CheckChange{
+			Category:  diffCategory(result),
+			Name:      result.Name,
+			ID:        result.ID,
+			NewStatus: result.Status,
+			Message:   result.Message,
+		}
+
+		before, existed := oldResults.byKey[key]
+		if !existed {
+			diff.Added = append(diff.Added, change)
+			continue
+		}
+
+		change.OldStatus = before.Status
+		switch {
+		case statusRank(result.Status) > statusRank(before.Status):
+			diff.Regressions = append(diff.Regressions, change)
+		case statusRank(result.Status) < statusRank(before.Status):
+			diff.Fixes = append(diff.Fixes, change)
+		}
+	}
+	for _, key := range oldResults.order {
+		if _, exists := newResults.byKey[key]; exists {
+			continue
+		}
+		result := oldResults.byKey[key]
+		diff.Removed = append(diff.Removed, CheckChange{
+			Category:  diffCategory(result),
+			Name:      result.Name,
+			ID:        result.ID,
+			OldStatus: result.Status,
+			Message:   result.Message,
+		})
+	}
+
+	return diff
+}
+
+// HasRegressions reports whether the score dropped or any check got worse
+func (d ReportDiff) HasRegressions() bool {
+	return d.ScoreDelta < 0 || len(d.Regressions) > 0
+}
+
+// WriteJSON writes the diff as indented JSON
+func (d ReportDiff) WriteJSON(writer io.Writer) error {
+	output, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff to JSON: %w", err)
+	}
+	if _, err := writer.Write(append(output, '\n')); err != nil {
+		return fmt.Errorf("failed to write JSON diff: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the diff for the terminal
+func (d ReportDiff) WriteText(writer io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Old: %s (%s)\n", d.Old.URL, d.Old.Timestamp.Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&b, "New: %s (%s)\n\n", d.New.URL, d.New.Timestamp.Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&b, "Overall score: %d (%s) → %d (%s)  %s\n", d.Old.Score, d.Old.Grade, d.New.Score, d.New.Grade, formatDelta(d.ScoreDelta))
+	if !d.Comparable {
+		fmt.Fprintf(&b, "⚠️  Scored with different profiles (%s vs. %s); score deltas are not comparable\n", profileLabel(d.Old.ScoringProfile), profileLabel(d.New.ScoringProfile))
+	}
+
+	b.WriteString("\nCategories:\n")
+	for _, category := range d.Categories {
+		switch {
+		case category.Added:
+			fmt.Fprintf(&b, "  %-16s %3s → %3d\n", categoryTitle(category.Category), "new", category.NewScore)
+		case category.Removed:
+			fmt.Fprintf(&b, "  %-16s %3d → removed\n", categoryTitle(category.Category), category.OldScore)
+		default:
+			fmt.Fprintf(&b, "  %-16s %3d → %3d  %s\n", categoryTitle(category.Category), category.OldScore, category.NewScore, formatDelta(category.Delta))
+		}
+	}
+
+	writeChanges := func(title string, changes []CheckChange) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (%d):\n", title, len(changes))
+		for _, change := range changes {
+			fmt.Fprintf(&b, "  %s/%s: ", change.Category, change.Name)
+			switch {
+			case change.OldStatus == "":
+				fmt.Fprintf(&b, "%s", change.NewStatus)
+			case change.NewStatus == "":
+				fmt.Fprintf(&b, "was %s", change.OldStatus)
+			default:
+				fmt.Fprintf(&b, "%s → %s", change.OldStatus, change.NewStatus)
+			}
+			if change.Message != "" {
+				fmt.Fprintf(&b, " (%s)", change.Message)
+			}
+			b.WriteString("\n")
+		}
+	}
+	writeChanges("Regressions", d.Regressions)
+	writeChanges("Fixes", d.Fixes)
+	writeChanges("New checks", d.Added)
+	writeChanges("Removed checks", d.Removed)
+
+	if len(d.Regressions)+len(d.Fixes)+len(d.Added)+len(d.Removed) == 0 {
+		b.WriteString("\nNo check changed status.\n")
+	}
+
+	if _, err := io.WriteString(writer, b.String()); err != nil {
+		return fmt.Errorf("failed to write diff: %w", err)
+	}
+	return nil
+}
+
+// newDiffSide summarizes a report for the diff header
+func newDiffSide(report models.WebsiteReport) DiffSide {
+	return DiffSide{
+		URL:            report.URL,
+		Timestamp:      report.Timestamp,
+		Score:          report.OverallScore,
+		Grade:          Grade(report.OverallScore),
+		ScoringProfile: report.ScoringProfile,
+	}
+}
+
+// indexedResults are results keyed for matching across reports, in report order
+type indexedResults struct {
+	byKey map[string]models.CheckResult
+	order []string
+}
+
+// indexResults keys results for matching, numbering repeats of the same check
+func indexResults(results []models.CheckResult) indexedResults {
+	index := indexedResults{byKey: make(map[string]models.CheckResult)}
+	seen := make(map[string]int)
+	for _, result := range results {
+		key := matchKey(result)
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+		}
+		index.byKey[key] = result
+		index.order = append(index.order, key)
+	}
+	return index
+}
+
+// diffCategory returns the category of a result, "other" when it has none
+func diffCategory(result models.CheckResult) string {
+	if result.Category == "" {
+		return "other"
+	}
+	return result.Category
+}
+
+// statusRank orders statuses from best to worst
+func statusRank(status models.Status) int {
+	switch status {
+	case models.StatusPass:
+		return 0
+	case models.StatusWarning:
+		return 1
+	case models.StatusFail:
+		return 2
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,88 @@
+package report
+
+import "github.com/checkly-go/checkly/pkg/models"
+
+// gradeThresholds lists the lowest score earning each letter grade, best grade first
+var gradeThresholds = []struct {
+	MinScore int
+	Grade    string
+}{
+	{97, "A+"},
+	{90, "A"},
+	{80, "B"},
+	{70, "C"},
+	{60, "D"},
+	{0, "F"},
+}
+
+// Grade returns the letter grade for a 0-100 score: A+ from 97, A from 90, B from 80, C from 70,
+// D from 60 and F below
+func Grade(score int) string {
+	for _, threshold := range gradeThresholds {
+		if score >= threshold.MinScore {
+			return threshold.Grade
+		}
+	}
+	return "F"
+}
+
+// CompleteScores fills the grade, per-category scores and result counts of a report stored before
+// they were recorded, keeping its overall score. Describe the results first so the category scores
+// weigh findings by severity.
+func CompleteScores(report *models.WebsiteReport) {
+	if report.Grade == "" {
+		report.Grade = Grade(report.OverallScore)
+	}
+	if len(report.Categories) == 0 {
+		report.Categories = ScoringProfileFor(*report).categoryScores(report.Results)
+		report.Passed, report.Warnings, report.Failed, report.Suppressed = countStatuses(report.Results)
+	}
+}
+
+// CategoryScores returns the per-category scores stored in a report, computing them with the
+// report's scoring profile for reports stored before they were recorded
+func CategoryScores(report models.WebsiteReport) map[string]models.CategoryScore {
+	categories := report.Categories
+	if len(categories) == 0 {
+		categories = ScoringProfileFor(report).categoryScores(report.Results)
+	}
+
+	scores := make(map[string]models.CategoryScore, len(categories))
+	for _, category := range categories {
+		scores[category.Category] = category
+	}
+	return scores
+}
+
+// categoryScores scores and tallies each category of results, in report order
+func (p *ScoringProfile) categoryScores(results []models.CheckResult) []models.CategoryScore {
+	grouped := groupByCategory(results)
+
+	var scores []models.CategoryScore
+	for _, key := range orderedCategories(grouped) {
+		score := p.CategoryScore(grouped[key])
+		category := models.CategoryScore{Category: key, Score: score, Grade: Grade(score)}
+		category.Passed, category.Warnings, category.Failed, category.Suppressed = countStatuses(grouped[key])
+		scores = append(scores, category)
+	}
+	return scores
+}
+
+// countStatuses tallies results by status; suppressed results are only counted as suppressed
+func countStatuses(results []models.CheckResult) (passed, warnings, failed, suppressed int) {
+	for _, result := range results {
+		if result.Suppressed {
+			suppressed++
+			continue
+		}
+		switch result.Status {
+		case models.StatusPass:
+			passed++
+		case models.StatusWarning:
+			warnings++
+		case models.StatusFail:
+			failed++
+		}
+	}
+	return passed, warnings, failed, suppressed
+}
@@ -0,0 +1,201 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+//go:embed templates/report.html
+var htmlTemplateSource string
+
+// htmlTemplate renders the single-file report; all CSS is inlined so it works offline
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"statusIcon":     statusIcon,
+	"scoreClass":     scoreClass,
+	"gaugeDash":      gaugeDash,
+	"formatTime":     func(t time.Time) string { return t.Format("2006-01-02 15:04:05 MST") },
+	"formatDuration": func(d time.Duration) string { return d.Round(time.Millisecond).String() },
+	"formatMS":       func(ms float64) string { return fmt.Sprintf("%.0f ms", ms) },
+}).Parse(htmlTemplateSource))
+
+// categoryOrder fixes the order of report sections; unknown categories follow alphabetically
+var categoryOrder = []string{"security", "email", "dns", "performance", "seo", "accessibility", "robots", "sitemap", "securitytxt"}
+
+// HTMLReporter renders website check reports as a self-contained HTML page
+type HTMLReporter struct {
+	Writer io.Writer
+}
+
+// NewHTMLReporter creates a new HTML reporter writing to writer
+func NewHTMLReporter(writer io.Writer) *HTMLReporter {
+	return &HTMLReporter{
+		Writer: writer,
+	}
+}
+
+// htmlReportData is the view model passed to the template
+type htmlReportData struct {
+	Report     models.WebsiteReport
+	Grade      string
+	Categories []htmlCategory
+	Passed     int
+	Warnings   int
+	Failed     int
+	Suppressed int
+}
+
+// htmlCategory is one report section
+type htmlCategory struct {
+	Key         string
+	Title       string
+	Description string
+	Score       int
+	Grade       string
+	Summary     CategorySummary
+	Results     []htmlResult
+}
+
+// htmlResult is a check result with its evidence pre-rendered as indented JSON
+type htmlResult struct {
+	models.CheckResult
+	EvidenceJSON string
+}
+
+// GenerateReport creates an HTML report from individual check results grouped by checker
+func (r *HTMLReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport writes a WebsiteReport as a standalone HTML document
+func (r *HTMLReporter) WriteReport(report models.WebsiteReport) error {
+	data := htmlReportData{Report: report, Grade: Grade(report.OverallScore)}
+
+	profile := ScoringProfileFor(report)
+	scores := CategoryScores(report)
+	grouped := groupByCategory(report.Results)
+	data.Passed, data.Warnings, data.Failed, data.Suppressed = countStatuses(report.Results)
+
+	for _, key := range orderedCategories(grouped) {
+		results := grouped[key]
+		category := htmlCategory{
+			Key:         key,
+			Title:       categoryTitle(key),
+			Description: getCategoryDescription(key),
+			Score:       scores[key].Score,
+			Grade:       scores[key].Grade,
+			Summary:     generateCategorySummary(profile, results),
+		}
+		for _, result := range results {
+			category.Results = append(category.Results, htmlResult{
+				CheckResult:  result,
+				EvidenceJSON: evidenceJSON(result.Evidence),
+			})
+		}
+		data.Categories = append(data.Categories, category)
+	}
+
+	if err := htmlTemplate.Execute(r.Writer, data); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+
+	return nil
+}
+
+// orderedCategories sorts categories by categoryOrder, then alphabetically
+func orderedCategories(grouped map[string][]models.CheckResult) []string {
+	rank := make(map[string]int)
+	for i, key := range categoryOrder {
+		rank[key] = i
+	}
+
+	keys := make([]string, 0, len(grouped))
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, iKnown := rank[keys[i]]
+		rj, jKnown := rank[keys[j]]
+		switch {
+		case iKnown && jKnown:
+			return ri < rj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+	return keys
+}
+
+// categoryTitle returns the section heading for a category
+func categoryTitle(category string) string {
+	titles := map[string]string{
+		"robots":        "Robots.txt",
+		"securitytxt":   "Security.txt",
+		"sitemap":       "XML Sitemap",
+		"seo":           "SEO",
+		"security":      "Security",
+		"email":         "Email Security",
+		"dns":           "DNS",
+		"performance":   "Performance",
+		"accessibility": "Accessibility",
+		"other":         "Other Checks",
+	}
+
+	if title, exists := titles[category]; exists {
+		return title
+	}
+	return category
+}
+
+// evidenceJSON renders evidence for the collapsible details, or "" when there is none
+func evidenceJSON(evidence map[string]any) string {
+	if len(evidence) == 0 {
+		return ""
+	}
+	output, err := json.MarshalIndent(evidence, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("evidence could not be rendered: %v", err)
+	}
+	return string(output)
+}
+
+// statusIcon returns the symbol shown next to a result
+func statusIcon(status models.Status) string {
+	switch status {
+	case models.StatusPass:
+		return "✓"
+	case models.StatusWarning:
+		return "!"
+	case models.StatusFail:
+		return "✕"
+	default:
+		return "?"
+	}
+}
+
+// scoreClass maps a 0-100 score to the gauge color
+func scoreClass(score int) string {
+	switch {
+	case score >= 80:
+		return "good"
+	case score >= 50:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+// gaugeDash returns the SVG stroke-dasharray drawing score percent of the gauge circle (r=54)
+func gaugeDash(score int) string {
+	const circumference = 339.292
+	filled := circumference * float64(score) / 100
+	return fmt.Sprintf("%.3f %.3f", filled, circumference-filled)
+}
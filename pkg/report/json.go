@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/checkly-go/checkly/pkg/models"
@@ -25,27 +26,50 @@ func NewJSONReporter(writer io.Writer, prettyPrint bool) *JSONReporter {
 
 // GenerateReport creates a comprehensive JSON report from individual check results
 func (r *JSONReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// NewWebsiteReport builds a WebsiteReport from individual check results grouped by checker, scored
+// with the default profile; re-score it with ScoringProfile.Apply to use another one
+func NewWebsiteReport(url string, results map[string][]models.CheckResult) models.WebsiteReport {
 	start := time.Now()
 
-	// Flatten all results into a single slice
+	// Flatten all results into a single slice, recording which checker produced each one
 	var allResults []models.CheckResult
-	for _, resultSet := range results {
-		allResults = append(allResults, resultSet...)
+	for category, resultSet := range results {
+		for _, result := range resultSet {
+			if result.Category == "" {
+				result.Category = category
+			}
+			allResults = append(allResults, result)
+		}
 	}
 
-	// Calculate overall score
-	score := calculateOverallScore(allResults)
-
-	// Create website report
 	report := models.WebsiteReport{
-		URL:          url,
-		Timestamp:    start,
-		Duration:     time.Since(start),
-		Results:      allResults,
-		OverallScore: score,
+		URL:       url,
+		Timestamp: start,
+		Duration:  time.Since(start),
+		Results:   allResults,
+	}
+	DescribeResults(report.Results)
+	DefaultScoringProfile().Apply(&report)
+	return report
+}
+
+// LoadReport reads a WebsiteReport previously written with -output json. Results of reports written
+// before check IDs existed are described from the catalog.
+func LoadReport(filename string) (*models.WebsiteReport, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
 	}
 
-	return r.WriteReport(report)
+	var report models.WebsiteReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	DescribeResults(report.Results)
+	return &report, nil
 }
 
 // WriteReport writes a WebsiteReport to the output in JSON format
@@ -90,9 +114,10 @@ func (r *JSONReporter) WriteSummaryReport(url string, results map[string][]model
 	}
 
 	// Generate category summaries
+	profile := DefaultScoringProfile()
 	totalResults := 0
 	for category, resultSet := range results {
-		categorySummary := generateCategorySummary(resultSet)
+		categorySummary := generateCategorySummary(profile, resultSet)
 		summary.Summary[category] = categorySummary
 		totalResults += len(resultSet)
 	}
@@ -102,7 +127,7 @@ func (r *JSONReporter) WriteSummaryReport(url string, results map[string][]model
 	for _, resultSet := range results {
 		allResults = append(allResults, resultSet...)
 	}
-	summary.Score = calculateOverallScore(allResults)
+	summary.Score = profile.Score(allResults)
 
 	// Marshal and write
 	var output []byte
@@ -132,19 +157,25 @@ type CategorySummary struct {
 	Passed      int           `json:"passed"`
 	Warnings    int           `json:"warnings"`
 	Failed      int           `json:"failed"`
+	Suppressed  int           `json:"suppressed,omitempty"`
 	Score       int           `json:"score"`
 	Status      models.Status `json:"overall_status"`
 	Issues      []string      `json:"issues,omitempty"`
 }
 
-// generateCategorySummary creates a summary for a category of checks
-func generateCategorySummary(results []models.CheckResult) CategorySummary {
+// generateCategorySummary creates a summary for a category of checks, scored with profile
+func generateCategorySummary(profile *ScoringProfile, results []models.CheckResult) CategorySummary {
 	summary := CategorySummary{
 		TotalChecks: len(results),
+		Score:       profile.CategoryScore(results),
 		Issues:      []string{},
 	}
 
 	for _, result := range results {
+		if result.Suppressed {
+			summary.Suppressed++
+			continue
+		}
 		switch result.Status {
 		case models.StatusPass:
 			summary.Passed++
@@ -157,16 +188,6 @@ func generateCategorySummary(results []models.CheckResult) CategorySummary {
 		}
 	}
 
-	// Calculate category score (0-100)
-	if summary.TotalChecks == 0 {
-		summary.Score = 0
-	} else {
-		passScore := summary.Passed * 100
-		warningScore := summary.Warnings * 50
-		totalPossible := summary.TotalChecks * 100
-		summary.Score = (passScore + warningScore) / totalPossible
-	}
-
 	// Determine overall status
 	if summary.Failed > 0 {
 		summary.Status = models.StatusFail
@@ -206,13 +227,14 @@ func (r *JSONReporter) WriteDetailedReport(url string, results map[string][]mode
 	}
 
 	// Process each category
+	profile := DefaultScoringProfile()
 	for category, resultSet := range results {
 		detailed.Categories[category] = DetailedCategory{
 			Name:        category,
 			Description: getCategoryDescription(category),
 			Results:     resultSet,
 		}
-		detailed.Summary[category] = generateCategorySummary(resultSet)
+		detailed.Summary[category] = generateCategorySummary(profile, resultSet)
 		detailed.Metadata.TotalChecks += len(resultSet)
 	}
 
@@ -221,7 +243,7 @@ func (r *JSONReporter) WriteDetailedReport(url string, results map[string][]mode
 	for _, resultSet := range results {
 		allResults = append(allResults, resultSet...)
 	}
-	detailed.Score = calculateOverallScore(allResults)
+	detailed.Score = profile.Score(allResults)
 
 	// Marshal and write
 	var output []byte
@@ -264,9 +286,12 @@ type ReportMetadata struct {
 func getCategoryDescription(category string) string {
 	descriptions := map[string]string{
 		"robots":        "Robots.txt file accessibility and configuration",
+		"securitytxt":   "security.txt vulnerability disclosure policy (RFC 9116)",
 		"sitemap":       "XML sitemap availability and structure validation",
 		"seo":           "Search Engine Optimization metadata and best practices",
 		"security":      "Security headers and information disclosure prevention",
+		"email":         "Email authentication and transport security (SPF, DMARC, DKIM, MTA-STS, TLS-RPT)",
+		"dns":           "DNS hygiene: CAA, DNSSEC, IPv6 availability and dangling CNAMEs",
 		"performance":   "Page loading speed and Core Web Vitals",
 		"accessibility": "Web accessibility compliance and best practices",
 	}
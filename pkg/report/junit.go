@@ -0,0 +1,154 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// JUnitReporter renders website check reports as JUnit XML so CI systems show them as test results
+type JUnitReporter struct {
+	Writer             io.Writer
+	WarningsAsFailures bool // Report warnings as failures instead of skipped tests
+}
+
+// NewJUnitReporter creates a new JUnit reporter; warnings are reported as skipped unless warningsAsFailures is set
+func NewJUnitReporter(writer io.Writer, warningsAsFailures bool) *JUnitReporter {
+	return &JUnitReporter{
+		Writer:             writer,
+		WarningsAsFailures: warningsAsFailures,
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// GenerateReport creates a JUnit report from individual check results grouped by checker
+func (r *JUnitReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport writes a WebsiteReport as JUnit XML: one testsuite per category, one testcase per result
+func (r *JUnitReporter) WriteReport(report models.WebsiteReport) error {
+	suites := junitTestSuites{Name: "checkly " + report.URL}
+
+	var total time.Duration
+	grouped := groupByCategory(report.Results)
+	for _, key := range orderedCategories(grouped) {
+		suite := junitTestSuite{
+			Name:       categoryTitle(key),
+			Timestamp:  report.Timestamp.Format("2006-01-02T15:04:05"),
+			Properties: []junitProperty{{Name: "url", Value: report.URL}, {Name: "category", Value: key}},
+		}
+
+		var elapsed time.Duration
+		for _, result := range grouped[key] {
+			testCase := junitTestCase{
+				Name:      result.Name,
+				ClassName: "checkly." + key,
+				Time:      junitSeconds(result.Duration),
+			}
+
+			text := result.Message
+			if result.Details != "" {
+				text += "\n" + result.Details
+			}
+			if result.Remediation != "" {
+				text += "\nFix: " + result.Remediation
+			}
+
+			switch {
+			case result.Suppressed && result.Status != models.StatusPass:
+				testCase.Skipped = &junitMessage{Message: "suppressed: " + result.SuppressionReason}
+				testCase.SystemOut = text
+				suite.Skipped++
+			case result.Status == models.StatusFail:
+				testCase.Failure = &junitMessage{Message: result.Message, Type: string(result.Status), Text: text}
+				suite.Failures++
+			case result.Status == models.StatusWarning && r.WarningsAsFailures:
+				testCase.Failure = &junitMessage{Message: result.Message, Type: string(result.Status), Text: text}
+				suite.Failures++
+			case result.Status == models.StatusWarning:
+				testCase.Skipped = &junitMessage{Message: result.Message}
+				testCase.SystemOut = text
+				suite.Skipped++
+			default:
+				testCase.SystemOut = text
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+			elapsed += result.Duration
+		}
+
+		suite.Tests = len(suite.TestCases)
+		suite.Time = junitSeconds(elapsed)
+		suites.Suites = append(suites.Suites, suite)
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		total += elapsed
+	}
+	suites.Time = junitSeconds(total)
+
+	output, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+
+	if _, err := io.WriteString(r.Writer, xml.Header); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	if _, err := r.Writer.Write(append(output, '\n')); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	return nil
+}
+
+// junitSeconds formats a duration the way JUnit expects: seconds with millisecond precision
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
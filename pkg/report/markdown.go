@@ -0,0 +1,209 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// MarkdownReporter renders website check reports as GitHub-flavored Markdown for PR comments and wikis
+type MarkdownReporter struct {
+	Writer   io.Writer
+	Baseline *models.WebsiteReport // Earlier report to compute score deltas against; optional
+}
+
+// NewMarkdownReporter creates a new Markdown reporter; baseline may be nil
+func NewMarkdownReporter(writer io.Writer, baseline *models.WebsiteReport) *MarkdownReporter {
+	return &MarkdownReporter{
+		Writer:   writer,
+		Baseline: baseline,
+	}
+}
+
+// GenerateReport creates a Markdown report from individual check results grouped by checker
+func (r *MarkdownReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport writes a WebsiteReport as Markdown: a summary table per category followed by
+// collapsible sections listing failures and warnings
+func (r *MarkdownReporter) WriteReport(report models.WebsiteReport) error {
+	var b strings.Builder
+
+	profile := ScoringProfileFor(report)
+	scores := CategoryScores(report)
+	grouped := groupByCategory(report.Results)
+	var baseline map[string]models.CategoryScore
+	var previous map[string]models.Status
+	if r.Baseline != nil {
+		baseline = CategoryScores(*r.Baseline)
+		previous = make(map[string]models.Status)
+		for _, result := range r.Baseline.Results {
+			previous[matchKey(result)] = result.Status
+		}
+	}
+
+	fmt.Fprintf(&b, "## Website report: %s\n\n", escapeMarkdown(report.URL))
+	fmt.Fprintf(&b, "**Overall score: %d/100 (%s)**", report.OverallScore, Grade(report.OverallScore))
+	if r.Baseline != nil {
+		fmt.Fprintf(&b, " (%s vs. baseline %d)", formatDelta(report.OverallScore-r.Baseline.OverallScore), r.Baseline.OverallScore)
+	}
+	if report.ScoringProfile != "" {
+		fmt.Fprintf(&b, " · profile `%s`", report.ScoringProfile)
+	}
+	fmt.Fprintf(&b, " · checked %s\n\n", report.Timestamp.Format("2006-01-02 15:04 MST"))
+	if r.Baseline != nil && r.Baseline.ScoringProfile != report.ScoringProfile {
+		fmt.Fprintf(&b, "> ⚠️ The baseline was scored with profile `%s`, so score deltas are not comparable.\n\n", profileLabel(r.Baseline.ScoringProfile))
+	}
+
+	if r.Baseline != nil {
+		b.WriteString("| Category | Score | Grade | Δ | ✅ Passed | ⚠️ Warnings | ❌ Failed |\n")
+		b.WriteString("|----------|------:|:-----:|--:|---------:|-----------:|---------:|\n")
+	} else {
+		b.WriteString("| Category | Score | Grade | ✅ Passed | ⚠️ Warnings | ❌ Failed |\n")
+		b.WriteString("|----------|------:|:-----:|---------:|-----------:|---------:|\n")
+	}
+
+	categories := orderedCategories(grouped)
+	for _, key := range categories {
+		summary := generateCategorySummary(profile, grouped[key])
+		score := scores[key]
+		fmt.Fprintf(&b, "| %s | %d | %s |", categoryTitle(key), score.Score, score.Grade)
+		if r.Baseline != nil {
+			delta := "new"
+			if previousScore, ok := baseline[key]; ok {
+				delta = formatDelta(score.Score - previousScore.Score)
+			}
+			fmt.Fprintf(&b, " %s |", delta)
+		}
+		fmt.Fprintf(&b, " %d | %d | %d |\n", summary.Passed, summary.Warnings, summary.Failed)
+	}
+
+	for _, key := range categories {
+		summary := generateCategorySummary(profile, grouped[key])
+		if summary.Failed == 0 && summary.Warnings == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n<details>\n<summary><b>%s</b>: %d failed, %d warnings</summary>\n\n", categoryTitle(key), summary.Failed, summary.Warnings)
+		// Failures first, then warnings, each in check order
+		for _, status := range []models.Status{models.StatusFail, models.StatusWarning} {
+			for _, result := range grouped[key] {
+				if result.Status != status || result.Suppressed {
+					continue
+				}
+				fmt.Fprintf(&b, "- %s **%s**: %s", markdownStatusIcon(result.Status), escapeMarkdown(result.Name), escapeMarkdown(result.Message))
+				if was, ok := previous[matchKey(result)]; ok && was != result.Status {
+					fmt.Fprintf(&b, " _(was %s)_", was)
+				} else if r.Baseline != nil && !ok {
+					b.WriteString(" _(new)_")
+				}
+				b.WriteString("\n")
+				if result.Details != "" {
+					fmt.Fprintf(&b, "  <br>%s\n", escapeMarkdown(result.Details))
+				}
+				if result.Remediation != "" {
+					fmt.Fprintf(&b, "  <br>**Fix** (%s): %s%s\n", result.Severity, escapeMarkdown(result.Remediation), markdownReferences(result.References))
+				}
+			}
+		}
+		b.WriteString("\n</details>\n")
+	}
+
+	var suppressed []models.CheckResult
+	for _, result := range report.Results {
+		if result.Suppressed {
+			suppressed = append(suppressed, result)
+		}
+	}
+	if len(suppressed) > 0 {
+		fmt.Fprintf(&b, "\n<details>\n<summary><b>Suppressed findings</b>: %d</summary>\n\n", len(suppressed))
+		for _, result := range suppressed {
+			fmt.Fprintf(&b, "- %s **%s**: %s _(%s)_\n", markdownStatusIcon(result.Status), escapeMarkdown(result.Name), escapeMarkdown(result.Message), escapeMarkdown(result.SuppressionReason))
+		}
+		b.WriteString("\n</details>\n")
+	}
+	for _, warning := range report.SuppressionWarnings {
+		fmt.Fprintf(&b, "\n> ⚠️ %s\n", escapeMarkdown(warning))
+	}
+
+	if _, err := io.WriteString(r.Writer, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+
+	return nil
+}
+
+// groupByCategory groups results by their category, using "other" for results without one
+func groupByCategory(results []models.CheckResult) map[string][]models.CheckResult {
+	grouped := make(map[string][]models.CheckResult)
+	for _, result := range results {
+		category := result.Category
+		if category == "" {
+			category = "other"
+		}
+		grouped[category] = append(grouped[category], result)
+	}
+	return grouped
+}
+
+// markdownReferences renders reference links after a remediation
+func markdownReferences(references []string) string {
+	if len(references) == 0 {
+		return ""
+	}
+	links := make([]string, len(references))
+	for i, reference := range references {
+		links[i] = fmt.Sprintf("[%d](%s)", i+1, reference)
+	}
+	return " " + strings.Join(links, " ")
+}
+
+// profileLabel names the profile of a report scored before profiles were recorded
+func profileLabel(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return name
+}
+
+// formatDelta renders a score change with an arrow
+func formatDelta(delta int) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("▲ +%d", delta)
+	case delta < 0:
+		return fmt.Sprintf("▼ %d", delta)
+	default:
+		return "±0"
+	}
+}
+
+// markdownStatusIcon returns the emoji used for a status
+func markdownStatusIcon(status models.Status) string {
+	switch status {
+	case models.StatusPass:
+		return "✅"
+	case models.StatusWarning:
+		return "⚠️"
+	case models.StatusFail:
+		return "❌"
+	default:
+		return "❔"
+	}
+}
+
+// escapeMarkdown keeps check text from breaking tables or being interpreted as HTML
+func escapeMarkdown(text string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		"|", "\\|",
+		"\r\n", " ",
+		"\n", " ",
+	)
+	return replacer.Replace(text)
+}
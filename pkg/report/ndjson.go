@@ -0,0 +1,45 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// NDJSONReporter writes newline-delimited JSON, one complete report per line, so bulk runs can be
+// streamed and processed as each URL finishes. It is safe for concurrent use.
+type NDJSONReporter struct {
+	Writer io.Writer
+	mu     sync.Mutex
+}
+
+// NewNDJSONReporter creates a new NDJSON reporter writing to writer
+func NewNDJSONReporter(writer io.Writer) *NDJSONReporter {
+	return &NDJSONReporter{
+		Writer: writer,
+	}
+}
+
+// GenerateReport writes a line for individual check results grouped by checker
+func (r *NDJSONReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport writes one report as a single line of JSON
+func (r *NDJSONReporter) WriteReport(report models.WebsiteReport) error {
+	output, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report to JSON: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.Writer.Write(append(output, '\n')); err != nil {
+		return fmt.Errorf("failed to write NDJSON report: %w", err)
+	}
+	return nil
+}
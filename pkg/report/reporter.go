@@ -0,0 +1,8 @@
+package report
+
+import "github.com/checkly-go/checkly/pkg/models"
+
+// Reporter writes a website report in one output format
+type Reporter interface {
+	WriteReport(report models.WebsiteReport) error
+}
@@ -0,0 +1,331 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// securityCategories are tagged "security" so code-scanning dashboards treat their findings as vulnerabilities
+var securityCategories = map[string]bool{"security": true, "securitytxt": true, "email": true, "dns": true}
+
+// nonAlphanumeric matches runs of characters that are replaced when building check IDs and rule names
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SARIFReporter renders website check reports as SARIF 2.1.0 for code-scanning tools
+type SARIFReporter struct {
+	Writer io.Writer
+}
+
+// NewSARIFReporter creates a new SARIF reporter writing to writer
+func NewSARIFReporter(writer io.Writer) *SARIFReporter {
+	return &SARIFReporter{
+		Writer: writer,
+	}
+}
+
+// sarifLog is the top-level SARIF document
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      sarifMessage        `json:"fullDescription"`
+	Help                 sarifMessage        `json:"help"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]any     `json:"properties,omitempty"`
+}
+
+// sarifSuppression marks a result accepted in the suppression file
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// GenerateReport creates a SARIF log from individual check results grouped by checker
+func (r *SARIFReporter) GenerateReport(url string, results map[string][]models.CheckResult) error {
+	return r.WriteReport(NewWebsiteReport(url, results))
+}
+
+// WriteReport writes a WebsiteReport as a SARIF log. Every check becomes a rule; only failures and
+// warnings become results, located at the audited URL.
+func (r *SARIFReporter) WriteReport(report models.WebsiteReport) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "ChecKly",
+			InformationURI: "https://github.com/checkly-go/checkly",
+			Version:        "1.0.0",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, result := range report.Results {
+		id := CheckID(result)
+		index, exists := ruleIndex[id]
+		if !exists {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[id] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(id, result))
+		}
+
+		level := sarifLevel(result.Status)
+		rule := &run.Tool.Driver.Rules[index]
+		// A rule's default level is the most severe level any of its results reached
+		if sarifLevelRank(level) > sarifLevelRank(rule.DefaultConfiguration.Level) {
+			rule.DefaultConfiguration.Level = level
+			rule.Properties.SecuritySeverity = sarifSecuritySeverity(result)
+			rule.Help.Text = sarifHelpText(result)
+		}
+		if rule.HelpURI == "" && len(result.References) > 0 {
+			rule.HelpURI = result.References[0]
+		}
+
+		if result.Status != models.StatusFail && result.Status != models.StatusWarning {
+			continue
+		}
+
+		message := fmt.Sprintf("%s: %s", result.Name, result.Message)
+		if result.Details != "" {
+			message += ". " + result.Details
+		}
+
+		sarif := sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     level,
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: report.URL},
+			}}},
+			PartialFingerprints: map[string]string{
+				"checkly/v1": sarifFingerprint(id, result.Name, report.URL),
+			},
+		}
+		if result.Suppressed {
+			sarif.Suppressions = []sarifSuppression{{Kind: "external", Justification: result.SuppressionReason}}
+		}
+		sarif.Properties = sarifResultProperties(result)
+		run.Results = append(run.Results, sarif)
+	}
+
+	document := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	output, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+
+	if _, err := r.Writer.Write(output); err != nil {
+		return fmt.Errorf("failed to write SARIF report: %w", err)
+	}
+
+	return nil
+}
+
+// newSARIFRule describes the check that produced result
+func newSARIFRule(id string, result models.CheckResult) sarifRule {
+	category := result.Category
+	if category == "" {
+		category = "other"
+	}
+
+	tags := []string{category}
+	if securityCategories[category] && category != "security" {
+		tags = append(tags, "security")
+	}
+
+	name := baseCheckName(result.Name)
+	description := fmt.Sprintf("%s check (%s)", name, getCategoryDescription(category))
+	help := description
+	if result.Status == models.StatusFail || result.Status == models.StatusWarning {
+		help = sarifHelpText(result)
+	}
+
+	return sarifRule{
+		ID:                   id,
+		Name:                 sarifPascalCase(name),
+		ShortDescription:     sarifMessage{Text: name},
+		FullDescription:      sarifMessage{Text: description},
+		Help:                 sarifMessage{Text: help},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(result.Status)},
+		Properties: sarifRuleProperties{
+			Tags:             tags,
+			SecuritySeverity: sarifSecuritySeverity(result),
+		},
+	}
+}
+
+// sarifPascalCase turns a check name into the identifier-style rule name SARIF viewers display
+func sarifPascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range nonAlphanumeric.Split(strings.ToLower(name), -1) {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// sarifHelpText explains the check using the most relevant result details available, followed by
+// the catalog remediation
+func sarifHelpText(result models.CheckResult) string {
+	text := result.Message
+	if result.Details != "" {
+		text = fmt.Sprintf("%s. %s", result.Message, result.Details)
+	}
+	if result.Remediation != "" {
+		text += "\n\nFix: " + result.Remediation
+	}
+	return text
+}
+
+// sarifResultProperties carries the finding ID, severity and evidence of a result
+func sarifResultProperties(result models.CheckResult) map[string]any {
+	properties := make(map[string]any)
+	if result.ID != "" {
+		properties["id"] = result.ID
+	}
+	if result.Severity != "" {
+		properties["severity"] = result.Severity
+	}
+	if len(result.Evidence) > 0 {
+		properties["evidence"] = result.Evidence
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// sarifLevel maps a check status to a SARIF level
+func sarifLevel(status models.Status) string {
+	switch status {
+	case models.StatusFail:
+		return "error"
+	case models.StatusWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLevelRank orders SARIF levels by severity
+func sarifLevelRank(level string) int {
+	switch level {
+	case "error":
+		return 2
+	case "warning":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sarifSecuritySeverity returns the CVSS-style score code-scanning dashboards use to rank security
+// findings, from the catalog severity or, for checks without one, from the status
+func sarifSecuritySeverity(result models.CheckResult) string {
+	if !securityCategories[result.Category] {
+		return ""
+	}
+	switch result.Severity {
+	case models.SeverityCritical:
+		return "9.5"
+	case models.SeverityHigh:
+		return "7.5"
+	case models.SeverityMedium:
+		return "5.0"
+	case models.SeverityLow:
+		return "3.0"
+	case models.SeverityInfo:
+		return "0.5"
+	}
+	switch result.Status {
+	case models.StatusFail:
+		return "7.5"
+	case models.StatusWarning:
+		return "4.0"
+	default:
+		return ""
+	}
+}
+
+// sarifFingerprint identifies a finding across runs so dashboards can track it
+func sarifFingerprint(ruleID, name, url string) string {
+	sum := sha256.Sum256([]byte(ruleID + "\x00" + name + "\x00" + url))
+	return hex.EncodeToString(sum[:16])
+}
@@ -1,24 +1,296 @@
 package report
 
-import "github.com/checkly-go/checkly/pkg/models"
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
-// calculateOverallScore computes an overall score from 0-100 based on all check results
-func calculateOverallScore(results []models.CheckResult) int {
-	if len(results) == 0 {
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+//go:embed profiles/*.json
+var profileFiles embed.FS
+
+// DefaultProfileName is the profile used when none is selected
+const DefaultProfileName = "default"
+
+// ScoringProfile weights categories and individual checks when computing scores. Every report
+// records the name of the profile that scored it; scores are only comparable under the same profile.
+type ScoringProfile struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Categories  map[string]float64 `json:"categories"`             // Category weights; unlisted categories weigh 1, 0 leaves a category out
+	Checks      map[string]float64 `json:"checks,omitempty"`       // Check weights within their category by rule ID or name; unlisted checks weigh 1
+	Penalties   Penalties          `json:"penalties"`              // Share of a check's weight lost per status and severity
+	Critical    []string           `json:"critical,omitempty"`     // Rule IDs or names of checks whose failure caps the overall score
+	CriticalCap int                `json:"critical_cap,omitempty"` // Highest overall score while a critical check fails
+}
+
+// Penalties is the fraction (0-1) of a check's weight lost for a warning or a failure. The status
+// penalty is multiplied by the finding's severity multiplier, and the result capped at 1; findings
+// without a severity use a multiplier of 1.
+type Penalties struct {
+	Warning  float64                     `json:"warning"`
+	Fail     float64                     `json:"fail"`
+	Severity map[models.Severity]float64 `json:"severity,omitempty"`
+}
+
+// penalty returns the share of a check's weight a finding loses
+func (p Penalties) penalty(status models.Status, severity models.Severity) float64 {
+	penalty := p.Warning
+	if status == models.StatusFail {
+		penalty = p.Fail
+	}
+	if multiplier, ok := p.Severity[severity]; ok {
+		penalty *= multiplier
+	}
+	return math.Min(penalty, 1)
+}
+
+// ScoringProfileNames lists the built-in profiles
+func ScoringProfileNames() []string {
+	entries, err := profileFiles.ReadDir("profiles")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultScoringProfile returns the built-in default profile
+func DefaultScoringProfile() *ScoringProfile {
+	profile, err := BuiltinScoringProfile(DefaultProfileName)
+	if err != nil {
+		panic(fmt.Sprintf("embedded scoring profile is invalid: %v", err))
+	}
+	return profile
+}
+
+// LoadScoringProfile returns the built-in profile with the given name, or reads a profile from a JSON
+// file. Penalties and severity multipliers missing from the file keep the defaults; a missing name is
+// taken from the file name.
+func LoadScoringProfile(nameOrFile string) (*ScoringProfile, error) {
+	if profile, err := BuiltinScoringProfile(nameOrFile); err == nil {
+		return profile, nil
+	}
+
+	data, err := os.ReadFile(nameOrFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring profile (built-in profiles: %s): %w", strings.Join(ScoringProfileNames(), ", "), err)
+	}
+
+	profile, err := parseScoringProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(nameOrFile), filepath.Ext(nameOrFile))
+	}
+	return profile, nil
+}
+
+// ScoringProfileFor returns the built-in profile a report was scored with, falling back to the
+// default for reports scored before profiles existed or with a custom profile file
+func ScoringProfileFor(report models.WebsiteReport) *ScoringProfile {
+	if profile, err := BuiltinScoringProfile(report.ScoringProfile); err == nil {
+		return profile
+	}
+	return DefaultScoringProfile()
+}
+
+// BuiltinScoringProfile returns the embedded profile with the given name
+func BuiltinScoringProfile(name string) (*ScoringProfile, error) {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return nil, fmt.Errorf("unknown scoring profile %q", name)
+	}
+	data, err := profileFiles.ReadFile("profiles/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown scoring profile %q", name)
+	}
+	return parseScoringProfile(data)
+}
+
+// parseScoringProfile decodes and validates a profile
+func parseScoringProfile(data []byte) (*ScoringProfile, error) {
+	profile := &ScoringProfile{
+		Penalties: Penalties{
+			Warning: 0.5,
+			Fail:    1,
+			Severity: map[models.Severity]float64{
+				models.SeverityInfo:     0.25,
+				models.SeverityLow:      0.5,
+				models.SeverityMedium:   0.75,
+				models.SeverityHigh:     1,
+				models.SeverityCritical: 1,
+			},
+		},
+		CriticalCap: 59,
+	}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("failed to parse scoring profile: %w", err)
+	}
+
+	for category, weight := range profile.Categories {
+		if weight < 0 {
+			return nil, fmt.Errorf("invalid scoring profile: category %q has a negative weight", category)
+		}
+	}
+	for check, weight := range profile.Checks {
+		if weight < 0 {
+			return nil, fmt.Errorf("invalid scoring profile: check %q has a negative weight", check)
+		}
+	}
+	for status, penalty := range map[string]float64{"warning": profile.Penalties.Warning, "fail": profile.Penalties.Fail} {
+		if penalty < 0 || penalty > 1 {
+			return nil, fmt.Errorf("invalid scoring profile: %s penalty must be between 0 and 1", status)
+		}
+	}
+	for severity, multiplier := range profile.Penalties.Severity {
+		if !knownSeverity(severity) {
+			return nil, fmt.Errorf("invalid scoring profile: unknown severity %q", severity)
+		}
+		if multiplier < 0 {
+			return nil, fmt.Errorf("invalid scoring profile: %s severity multiplier is negative", severity)
+		}
+	}
+	if profile.CriticalCap < 0 || profile.CriticalCap > 100 {
+		return nil, fmt.Errorf("invalid scoring profile: critical_cap must be between 0 and 100")
+	}
+
+	return profile, nil
+}
+
+// Apply scores a report with the profile: the overall and per-category scores, their grades and
+// the result counts. The profile name is recorded in the report.
+func (p *ScoringProfile) Apply(report *models.WebsiteReport) {
+	report.OverallScore = p.Score(report.Results)
+	report.Grade = Grade(report.OverallScore)
+	report.ScoringProfile = p.Name
+	report.Passed, report.Warnings, report.Failed, report.Suppressed = countStatuses(report.Results)
+	report.Categories = p.categoryScores(report.Results)
+}
+
+// Score computes the 0-100 overall score: the category scores averaged by category weight, capped
+// while a critical check fails
+func (p *ScoringProfile) Score(results []models.CheckResult) int {
+	var weighted, total float64
+	for category, categoryResults := range groupByCategory(results) {
+		score, ok := p.categoryScore(categoryResults)
+		weight := p.categoryWeight(category)
+		if !ok || weight == 0 {
+			continue
+		}
+		weighted += score * weight
+		total += weight
+	}
+	if total == 0 {
 		return 0
 	}
 
-	totalScore := 0
+	score := int(math.Round(weighted / total))
+	if p.criticalFailure(results) && score > p.CriticalCap {
+		score = p.CriticalCap
+	}
+	return score
+}
+
+// CategoryScore computes the 0-100 score of one category's results from the check weights and penalties
+func (p *ScoringProfile) CategoryScore(results []models.CheckResult) int {
+	score, _ := p.categoryScore(results)
+	return int(math.Round(score))
+}
+
+// categoryScore returns the unrounded category score; warnings and failures lose a share of their
+// weight that grows with their severity. Suppressed results don't count; when no result carries
+// weight, as when every finding is suppressed, nothing is outstanding and it returns 100 and false so
+// the category stays out of the overall score.
+func (p *ScoringProfile) categoryScore(results []models.CheckResult) (float64, bool) {
+	var earned, total float64
 	for _, result := range results {
+		if result.Suppressed {
+			continue
+		}
+		weight := p.checkWeight(result)
 		switch result.Status {
 		case models.StatusPass:
-			totalScore += 100
-		case models.StatusWarning:
-			totalScore += 50
-		case models.StatusFail:
-			totalScore += 0
+			earned += weight
+		case models.StatusWarning, models.StatusFail:
+			earned += weight * (1 - p.Penalties.penalty(result.Status, result.Severity))
+		default:
+			continue
 		}
+		total += weight
+	}
+	if total == 0 {
+		return 100, false
 	}
+	return 100 * earned / total, true
+}
+
+// categoryWeight returns the weight of a category, 1 when the profile doesn't list it
+func (p *ScoringProfile) categoryWeight(category string) float64 {
+	if weight, ok := p.Categories[category]; ok {
+		return weight
+	}
+	return 1
+}
+
+// checkWeight returns the weight of a result's check; see profileKeys for how profile entries match
+func (p *ScoringProfile) checkWeight(result models.CheckResult) float64 {
+	for _, key := range profileKeys(result) {
+		if weight, ok := p.Checks[key]; ok {
+			return weight
+		}
+	}
+	return 1
+}
 
-	return totalScore / len(results)
+// criticalFailure reports whether any check listed as critical failed, ignoring suppressed results
+func (p *ScoringProfile) criticalFailure(results []models.CheckResult) bool {
+	for _, result := range results {
+		if result.Status != models.StatusFail || result.Suppressed {
+			continue
+		}
+		for _, key := range profileKeys(result) {
+			for _, critical := range p.Critical {
+				if key == critical {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// profileKeys lists the keys a profile may use for a result's check, most specific first: the stable
+// rule ID (from the catalog when the result has none), then the full name, then the name without the
+// per-finding suffix, e.g. "Sensitive File Exposure (/.env)" matches "Sensitive File Exposure"
+func profileKeys(result models.CheckResult) []string {
+	var keys []string
+	if result.Rule != "" {
+		keys = append(keys, result.Rule)
+	} else if definition := lookupCheck(result.Name); definition != nil {
+		keys = append(keys, definition.ID)
+	}
+	return append(keys, result.Name, baseCheckName(result.Name))
+}
+
+// baseCheckName drops the per-finding suffix from names like "Sensitive File Exposure (/.env)"
+// so every finding of the same check shares one name
+func baseCheckName(name string) string {
+	if strings.HasSuffix(name, ")") {
+		if i := strings.LastIndex(name, " ("); i > 0 {
+			return name[:i]
+		}
+	}
+	return name
 }
@@ -0,0 +1,156 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/checkly-go/checkly/pkg/models"
+)
+
+// suppressionExpiryNotice is how long before expiry a suppression starts producing warnings
+const suppressionExpiryNotice = 14 * 24 * time.Hour
+
+// SuppressionList is the suppression file: findings accepted as known risks
+type SuppressionList struct {
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// Suppression accepts the findings of one check, optionally only on matching URLs and until a date
+type Suppression struct {
+	Check   string `json:"check"`             // Finding or check ID such as security.x-xss-protection.*, or check name; * matches anything
+	URL     string `json:"url,omitempty"`     // URL pattern; * matches anything, empty matches every URL
+	Reason  string `json:"reason"`            // Why the finding is accepted
+	Expires string `json:"expires,omitempty"` // Last day (YYYY-MM-DD) the suppression applies; empty never expires
+
+	expires      time.Time
+	checkPattern *regexp.Regexp
+	urlPattern   *regexp.Regexp
+}
+
+// LoadSuppressions reads and validates a suppression file
+func LoadSuppressions(filename string) (*SuppressionList, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppression file: %w", err)
+	}
+
+	var list SuppressionList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse suppression file: %w", err)
+	}
+
+	for i := range list.Suppressions {
+		suppression := &list.Suppressions[i]
+		if suppression.Check == "" {
+			return nil, fmt.Errorf("invalid suppression %d: check is required", i+1)
+		}
+		if strings.TrimSpace(suppression.Reason) == "" {
+			return nil, fmt.Errorf("invalid suppression %d (%s): reason is required", i+1, suppression.Check)
+		}
+		if suppression.Expires != "" {
+			expires, err := time.Parse("2006-01-02", suppression.Expires)
+			if err != nil {
+				return nil, fmt.Errorf("invalid suppression %d (%s): expires must be a YYYY-MM-DD date", i+1, suppression.Check)
+			}
+			// The suppression covers the whole expiry day
+			suppression.expires = expires.AddDate(0, 0, 1)
+		}
+		suppression.checkPattern = globPattern(suppression.Check)
+		if suppression.URL != "" {
+			suppression.urlPattern = globPattern(suppression.URL)
+		}
+	}
+
+	return &list, nil
+}
+
+// Apply marks the results of report covered by an active suppression as suppressed and records
+// warnings for suppressions of this URL that have expired or expire within two weeks. Findings of an
+// expired suppression count again. Re-score the report afterwards.
+func (l *SuppressionList) Apply(report *models.WebsiteReport, now time.Time) {
+	report.SuppressionWarnings = nil
+	warned := make(map[int]bool)
+
+	for i := range report.Results {
+		result := &report.Results[i]
+		result.Suppressed = false
+		result.SuppressionReason = ""
+		if result.Status == models.StatusPass {
+			continue
+		}
+
+		for j, suppression := range l.Suppressions {
+			if !suppression.matches(*result, report.URL) {
+				continue
+			}
+
+			expired := !suppression.expires.IsZero() && !now.Before(suppression.expires)
+			if !warned[j] {
+				switch {
+				case expired:
+					report.SuppressionWarnings = append(report.SuppressionWarnings, fmt.Sprintf("suppression of %s expired on %s; its findings count again (%s)", suppression.Check, suppression.Expires, suppression.Reason))
+					warned[j] = true
+				case !suppression.expires.IsZero() && suppression.expires.Sub(now) <= suppressionExpiryNotice:
+					report.SuppressionWarnings = append(report.SuppressionWarnings, fmt.Sprintf("suppression of %s expires on %s (%s)", suppression.Check, suppression.Expires, suppression.Reason))
+					warned[j] = true
+				}
+			}
+			if expired {
+				continue
+			}
+
+			result.Suppressed = true
+			result.SuppressionReason = suppression.Reason
+			break
+		}
+	}
+}
+
+// HasFindings reports whether any result that isn't suppressed has at least the given status, e.g.
+// StatusWarning matches warnings and failures; it drives the exit code of CI runs
+func HasFindings(report models.WebsiteReport, minimum models.Status) bool {
+	for _, result := range report.Results {
+		if !result.Suppressed && statusRank(result.Status) >= statusRank(minimum) && statusRank(result.Status) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the suppression covers result on the audited URL
+func (s Suppression) matches(result models.CheckResult, url string) bool {
+	if s.urlPattern != nil && !s.urlPattern.MatchString(url) {
+		return false
+	}
+	for _, id := range []string{result.ID, result.Rule, CheckID(result), result.Name} {
+		if id != "" && s.checkPattern.MatchString(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckID identifies the check behind a result by its category and name without the per-finding
+// suffix, e.g. security/content-security-policy. It predates the catalog IDs in result.Rule and is
+// kept for SARIF rule IDs and existing suppression files.
+func CheckID(result models.CheckResult) string {
+	category := result.Category
+	if category == "" {
+		category = "other"
+	}
+	slug := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(baseCheckName(result.Name)), "-"), "-")
+	return category + "/" + slug
+}
+
+// globPattern compiles a pattern in which * matches any run of characters, case-insensitively
+func globPattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("(?i)^" + strings.Join(parts, ".*") + "$")
+}